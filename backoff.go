@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BackoffKind determines how the delay grows with each attempt.
+type BackoffKind int
+
+const (
+	// BackoffExponential multiplies the delay by the factor for each attempt.
+	BackoffExponential BackoffKind = iota
+	// BackoffLinear adds the initial delay for each attempt.
+	BackoffLinear
+	// BackoffConstant always uses the initial delay.
+	BackoffConstant
+)
+
+// Backoff computes delays between retry attempts.
+type Backoff struct {
+	Kind BackoffKind
+
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+
+	// Max caps the delay. Zero means no cap.
+	Max time.Duration
+
+	// Factor is the multiplier used by BackoffExponential. It defaults to 2.
+	Factor float64
+
+	// Jitter randomizes each delay by up to the given fraction in either direction, e.g. 0.1 for ±10%.
+	Jitter float64
+}
+
+// ParseBackoff parses a backoff specification of the form "<initial>[..<max>] [x<factor>] [~<jitter>]", e.g.
+// "1s..2m x2 ~0.1". Durations are parsed using ParseDuration. A factor turns on exponential backoff and a factor of 1
+// constant backoff. Without a factor and with a maximum, the backoff is exponential with a factor of 2, and without
+// both it is constant. Instead of a factor, "linear" may be given.
+func ParseBackoff(spec string) (*Backoff, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid backoff: %q", spec)
+	}
+
+	b := &Backoff{Kind: BackoffConstant}
+	bounds := strings.SplitN(fields[0], "..", 2)
+	var err error
+	if b.Initial, err = ParseDuration(bounds[0]); err != nil {
+		return nil, err
+	}
+	if len(bounds) == 2 {
+		if b.Max, err = ParseDuration(bounds[1]); err != nil {
+			return nil, err
+		}
+		b.Kind = BackoffExponential
+	}
+
+	for _, f := range fields[1:] {
+		switch {
+		case f == "linear":
+			b.Kind = BackoffLinear
+		case strings.HasPrefix(f, "x"):
+			if b.Factor, err = strconv.ParseFloat(f[1:], 64); err != nil || b.Factor < 1 {
+				return nil, fmt.Errorf("invalid factor %q in backoff", f)
+			}
+			b.Kind = BackoffExponential
+			if b.Factor == 1 {
+				b.Kind = BackoffConstant
+			}
+		case strings.HasPrefix(f, "~"):
+			if b.Jitter, err = strconv.ParseFloat(f[1:], 64); err != nil || b.Jitter < 0 || b.Jitter > 1 {
+				return nil, fmt.Errorf("invalid jitter %q in backoff", f)
+			}
+		default:
+			return nil, fmt.Errorf("invalid backoff: %q", spec)
+		}
+	}
+	return b, nil
+}
+
+// Next returns the delay before the given retry attempt, counted from 0.
+func (b *Backoff) Next(attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+
+	d := float64(b.Initial)
+	switch b.Kind {
+	case BackoffExponential:
+		factor := b.Factor
+		if factor == 0 {
+			factor = 2
+		}
+		d *= math.Pow(factor, float64(attempt))
+	case BackoffLinear:
+		d *= float64(attempt + 1)
+	}
+
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (2*rand.Float64() - 1)
+	}
+	if b.Max > 0 && d > float64(b.Max) {
+		return b.Max
+	} else if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
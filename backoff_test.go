@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseBackoff(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    *Backoff
+		wantErr bool
+	}{
+		{spec: "1s", want: &Backoff{Kind: BackoffConstant, Initial: time.Second}},
+		{spec: "1s..2m", want: &Backoff{Kind: BackoffExponential, Initial: time.Second, Max: 2 * time.Minute}},
+		{
+			spec: " 100ms..1m x3 ~0.1 ",
+			want: &Backoff{Kind: BackoffExponential, Initial: 100 * time.Millisecond, Max: time.Minute, Factor: 3, Jitter: 0.1},
+		},
+		{spec: "1s x1.5", want: &Backoff{Kind: BackoffExponential, Initial: time.Second, Factor: 1.5}},
+		{spec: "1s..1m x1", want: &Backoff{Kind: BackoffConstant, Initial: time.Second, Max: time.Minute, Factor: 1}},
+		{spec: "1s..1m linear", want: &Backoff{Kind: BackoffLinear, Initial: time.Second, Max: time.Minute}},
+		{spec: "", wantErr: true},
+		{spec: "abc", wantErr: true},
+		{spec: "1s..abc", wantErr: true},
+		{spec: "1s x0.5", wantErr: true},
+		{spec: "1s xabc", wantErr: true},
+		{spec: "1s ~2", wantErr: true},
+		{spec: "1s ~-0.1", wantErr: true},
+		{spec: "1s foo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseBackoff(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBackoff(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBackoff(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffNext(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Backoff
+		want []time.Duration
+	}{
+		{
+			name: "exponential",
+			b:    Backoff{Kind: BackoffExponential, Initial: time.Second, Max: 10 * time.Second},
+			want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second},
+		},
+		{
+			name: "factor",
+			b:    Backoff{Kind: BackoffExponential, Initial: time.Second, Factor: 3},
+			want: []time.Duration{time.Second, 3 * time.Second, 9 * time.Second},
+		},
+		{
+			name: "linear",
+			b:    Backoff{Kind: BackoffLinear, Initial: time.Second},
+			want: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			name: "constant",
+			b:    Backoff{Kind: BackoffConstant, Initial: time.Second},
+			want: []time.Duration{time.Second, time.Second, time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for attempt, want := range tt.want {
+				if got := tt.b.Next(attempt); got != want {
+					t.Errorf("Next(%d) = %v, want %v", attempt, got, want)
+				}
+			}
+		})
+	}
+
+	b := Backoff{Kind: BackoffExponential, Initial: time.Second}
+	if got := b.Next(-1); got != time.Second {
+		t.Errorf("Next(-1) = %v, want %v", got, time.Second)
+	}
+	if got := b.Next(100); got != math.MaxInt64 {
+		t.Errorf("Next(100) = %v, want %v", got, time.Duration(math.MaxInt64))
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := Backoff{Kind: BackoffConstant, Initial: time.Second, Jitter: 0.5}
+
+	varied := false
+	for i := 0; i < 100; i++ {
+		got := b.Next(0)
+		if got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("Next(0) = %v, want within 500ms..1.5s", got)
+		}
+		if got != time.Second {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Error("Next() did not apply any jitter")
+	}
+}
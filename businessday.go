@@ -0,0 +1,89 @@
+package tools
+
+import "time"
+
+// BusinessCalendar defines which days are business days. Days falling on a weekend day or a holiday are not. It is
+// not safe to modify a calendar while it is in use.
+type BusinessCalendar struct {
+	// Weekend lists the days of the week that are not business days.
+	Weekend Set[time.Weekday]
+
+	// Holidays lists dates that are not business days, formatted as "2006-01-02".
+	Holidays Set[string]
+}
+
+// NewBusinessCalendar returns a calendar with Saturday and Sunday as weekend and the given holidays, which are
+// times whose date is used.
+func NewBusinessCalendar(holidays ...time.Time) *BusinessCalendar {
+	c := &BusinessCalendar{
+		Weekend:  NewSet(time.Saturday, time.Sunday),
+		Holidays: NewSet[string](),
+	}
+	c.AddHolidays(holidays...)
+	return c
+}
+
+// DefaultBusinessCalendar is the calendar used by IsBusinessDay, AddBusinessDays and BusinessDaysBetween.
+var DefaultBusinessCalendar = NewBusinessCalendar()
+
+// AddHolidays adds the dates of the given times as holidays.
+func (c *BusinessCalendar) AddHolidays(holidays ...time.Time) {
+	for _, h := range holidays {
+		c.Holidays.Add(h.Format("2006-01-02"))
+	}
+}
+
+// IsBusinessDay checks whether the date of t, in t's location, is a business day.
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	return !c.Weekend.Contains(t.Weekday()) && !c.Holidays.Contains(t.Format("2006-01-02"))
+}
+
+// AddBusinessDays moves t by n business days, backwards if n is negative. The time of day is kept.
+func (c *BusinessCalendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsBusinessDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// BusinessDaysBetween counts the business days from the date of a up to, but excluding, the date of b. Both dates
+// are taken in the location of a. If b is before a, the result is negative.
+func (c *BusinessCalendar) BusinessDaysBetween(a, b time.Time) int {
+	b = b.In(a.Location())
+	sign := 1
+	if b.Before(a) {
+		a, b, sign = b, a, -1
+	}
+
+	n := 0
+	day := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, a.Location())
+	end := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, a.Location())
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(day) {
+			n++
+		}
+	}
+	return sign * n
+}
+
+// IsBusinessDay checks whether t is a business day according to DefaultBusinessCalendar.
+func IsBusinessDay(t time.Time) bool {
+	return DefaultBusinessCalendar.IsBusinessDay(t)
+}
+
+// AddBusinessDays moves t by n business days according to DefaultBusinessCalendar.
+func AddBusinessDays(t time.Time, n int) time.Time {
+	return DefaultBusinessCalendar.AddBusinessDays(t, n)
+}
+
+// BusinessDaysBetween counts the business days between a and b according to DefaultBusinessCalendar.
+func BusinessDaysBetween(a, b time.Time) int {
+	return DefaultBusinessCalendar.BusinessDaysBetween(a, b)
+}
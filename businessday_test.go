@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessCalendar(t *testing.T) {
+	date := func(day, hour int) time.Time { return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC) }
+	c := NewBusinessCalendar(date(22, 0))
+	plus5 := time.FixedZone("UTC+5", 5*60*60)
+
+	isBusinessDay := map[int]bool{15: true, 19: true, 20: false, 21: false, 22: false, 23: true}
+	for day, want := range isBusinessDay {
+		if got := c.IsBusinessDay(date(day, 12)); got != want {
+			t.Errorf("IsBusinessDay(%v) = %v, want %v", date(day, 12), got, want)
+		}
+	}
+
+	addTests := []struct {
+		t    time.Time
+		n    int
+		want time.Time
+	}{
+		{t: date(15, 9), n: 1, want: date(16, 9)},
+		{t: date(19, 9), n: 1, want: date(23, 9)},
+		{t: date(15, 9), n: 5, want: date(23, 9)},
+		{t: date(15, 9), n: -1, want: date(12, 9)},
+		{t: date(23, 9), n: -1, want: date(19, 9)},
+		{t: date(20, 9), n: 0, want: date(20, 9)},
+	}
+	for _, tt := range addTests {
+		if got := c.AddBusinessDays(tt.t, tt.n); !got.Equal(tt.want) {
+			t.Errorf("AddBusinessDays(%v, %d) = %v, want %v", tt.t, tt.n, got, tt.want)
+		}
+	}
+
+	betweenTests := []struct {
+		a, b time.Time
+		want int
+	}{
+		{a: date(15, 0), b: date(15, 23), want: 0},
+		{a: date(15, 18), b: date(16, 9), want: 1},
+		{a: date(15, 0), b: date(22, 0), want: 5},
+		{a: date(15, 0), b: date(24, 0), want: 6},
+		{a: date(24, 0), b: date(15, 0), want: -6},
+		{a: date(20, 0), b: date(22, 0), want: 0},
+		// The date of b is taken in the location of a
+		{a: date(15, 0), b: time.Date(2024, 1, 16, 2, 0, 0, 0, plus5), want: 0},
+		{a: date(16, 0), b: time.Date(2024, 1, 16, 3, 0, 0, 0, plus5), want: -1},
+	}
+	for _, tt := range betweenTests {
+		if got := c.BusinessDaysBetween(tt.a, tt.b); got != tt.want {
+			t.Errorf("BusinessDaysBetween(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultBusinessCalendar(t *testing.T) {
+	sat := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	if IsBusinessDay(sat) {
+		t.Errorf("IsBusinessDay(%v) = true, want false", sat)
+	}
+	if got, want := AddBusinessDays(sat, 1), sat.AddDate(0, 0, 2); !got.Equal(want) {
+		t.Errorf("AddBusinessDays(%v, 1) = %v, want %v", sat, got, want)
+	}
+	if got := BusinessDaysBetween(sat, sat.AddDate(0, 0, 7)); got != 5 {
+		t.Errorf("BusinessDaysBetween() = %d, want 5", got)
+	}
+}
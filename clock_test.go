@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	if got := c.Now(); !got.Equal(start) {
+		t.Fatalf("Now() = %v, want %v", got, start)
+	}
+
+	after := c.After(time.Minute)
+	c.Advance(59 * time.Second)
+	select {
+	case <-after:
+		t.Fatal("After() fired before its deadline")
+	default:
+	}
+
+	c.Advance(time.Second)
+	select {
+	case got := <-after:
+		if want := start.Add(time.Minute); !got.Equal(want) {
+			t.Errorf("After() sent %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("After() did not fire at its deadline")
+	}
+
+	if got := c.NewTimer(0).C(); len(got) != 1 {
+		t.Error("NewTimer(0) did not fire immediately")
+	}
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.NewTimer(time.Minute)
+	if !timer.Stop() {
+		t.Error("Stop() on pending timer = false, want true")
+	}
+	if timer.Stop() {
+		t.Error("Stop() on stopped timer = true, want false")
+	}
+	c.Advance(time.Hour)
+	if len(timer.C()) != 0 {
+		t.Fatal("stopped timer fired")
+	}
+
+	if timer.Reset(time.Minute) {
+		t.Error("Reset() on stopped timer = true, want false")
+	}
+	if !timer.Reset(2 * time.Minute) {
+		t.Error("Reset() on pending timer = false, want true")
+	}
+	c.Set(start.Add(time.Hour + time.Minute))
+	if len(timer.C()) != 0 {
+		t.Fatal("timer fired before its reset deadline")
+	}
+	c.Set(start.Add(time.Hour + 2*time.Minute))
+	if len(timer.C()) != 1 {
+		t.Fatal("timer did not fire at its reset deadline")
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Second)
+		close(done)
+	}()
+
+	// Advance until the sleeping goroutine has registered its timer and woken up
+	deadline := time.After(5 * time.Second)
+	for {
+		c.Advance(time.Second)
+		select {
+		case <-done:
+			return
+		case <-deadline:
+			t.Fatal("Sleep() did not return")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+	if now.Before(before) || now.Sub(before) > time.Second {
+		t.Errorf("Now() = %v, want about %v", now, before)
+	}
+
+	timer := SystemClock.NewTimer(time.Hour)
+	if !timer.Stop() {
+		t.Error("Stop() on pending timer = false, want true")
+	}
+	select {
+	case <-SystemClock.After(time.Millisecond):
+	case <-time.After(5 * time.Second):
+		t.Error("After() did not fire")
+	}
+}
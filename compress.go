@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	bzip2Magic = []byte("BZh")
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// compressedReadCloser closes both the decompressor and the underlying file.
+type compressedReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *compressedReadCloser) Close() error {
+	var err error
+	for _, c := range r.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// OpenCompressed opens the given file for reading and transparently decompresses gzip, bzip2 and zstd content,
+// which is detected by its magic bytes. Other content is returned as it is.
+func OpenCompressed(path string) (io.ReadCloser, error) {
+	h, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(h)
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		h.Close()
+		return nil, err
+	}
+
+	rc := &compressedReadCloser{Reader: br, closers: []io.Closer{h}}
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			h.Close()
+			return nil, err
+		}
+		rc.Reader, rc.closers = zr, []io.Closer{zr, h}
+	case bytes.HasPrefix(magic, bzip2Magic):
+		rc.Reader = bzip2.NewReader(br)
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			h.Close()
+			return nil, err
+		}
+		rc.Reader, rc.closers = zr, []io.Closer{zr.IOReadCloser(), h}
+	}
+	return rc, nil
+}
+
+// WithCompression compresses the saved file according to its extension: ".gz" selects gzip and ".zst" or ".zstd"
+// selects zstd. Files with other extensions are written uncompressed. Writing bzip2 is not supported.
+func WithCompression() SaveOption {
+	return func(o *saveOptions) {
+		o.compress = true
+	}
+}
+
+// SaveFileCompressed safely writes data to a file like SaveFile, compressing it according to the file extension,
+// see WithCompression.
+func SaveFileCompressed(file string, data []byte, perm os.FileMode, opts ...SaveOption) error {
+	return SaveFile(file, data, perm, append(opts, WithCompression())...)
+}
+
+// newCompressWriter returns a writer compressing to w in the format matching the extension of file.
+func newCompressWriter(w io.Writer, file string) (io.WriteCloser, error) {
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".gz":
+		return gzip.NewWriter(w), nil
+	case ".zst", ".zstd":
+		return zstd.NewWriter(w)
+	case ".bz2":
+		return nil, fmt.Errorf("unsupported compression format: %q", ext)
+	}
+	return nopWriteCloser{w}, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestSaveFileCompressed(t *testing.T) {
+	dir := t.TempDir()
+	data := []byte(strings.Repeat("compressible data\n", 1000))
+
+	tests := []struct {
+		name  string
+		magic []byte
+	}{
+		{name: "data.gz", magic: gzipMagic},
+		{name: "data.zst", magic: zstdMagic},
+		{name: "data.ZSTD", magic: zstdMagic},
+		{name: "data.txt", magic: data[:4]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, tt.name)
+			if err := SaveFileCompressed(file, data, 0644); err != nil {
+				t.Fatalf("SaveFileCompressed(%q) failed: %v", tt.name, err)
+			}
+			raw, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(raw, tt.magic) {
+				t.Errorf("SaveFileCompressed(%q) wrote % x..., want prefix % x", tt.name, raw[:4], tt.magic)
+			}
+
+			rc, err := OpenCompressed(file)
+			if err != nil {
+				t.Fatalf("OpenCompressed(%q) failed: %v", tt.name, err)
+			}
+			defer rc.Close()
+			got, err := io.ReadAll(rc)
+			if err != nil || !bytes.Equal(got, data) {
+				t.Errorf("OpenCompressed(%q) read %d bytes, %v, want %d", tt.name, len(got), err, len(data))
+			}
+		})
+	}
+
+	if err := SaveFileCompressed(filepath.Join(dir, "data.bz2"), data, 0644); err == nil {
+		t.Error("SaveFileCompressed() with bzip2 returned no error")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "data.bz2")); !os.IsNotExist(err) {
+		t.Error("SaveFileCompressed() left a file behind after failing")
+	}
+}
+
+func TestOpenCompressed(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("hello gzip"))
+	zw.Close()
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zst := enc.EncodeAll([]byte("hello zstd"), nil)
+	enc.Close()
+
+	// "hello bzip2" compressed with bzip2
+	bz2, _ := hex.DecodeString("425a6839314159265359555a44f70000021980400010001264c0102000220069ea100305d3b62183c5dc914e14241556913dc0")
+
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		// The content is detected regardless of the extension
+		{name: "gzip", file: write("gzip.dat", gz.Bytes()), want: "hello gzip"},
+		{name: "zstd", file: write("zstd.dat", zst), want: "hello zstd"},
+		{name: "bzip2", file: write("bzip2.dat", bz2), want: "hello bzip2"},
+		{name: "plain", file: write("plain.gz", []byte("hello plain")), want: "hello plain"},
+		{name: "short", file: write("short", []byte("a")), want: "a"},
+		{name: "empty", file: write("empty", nil), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, err := OpenCompressed(tt.file)
+			if err != nil {
+				t.Fatalf("OpenCompressed() failed: %v", err)
+			}
+			got, err := io.ReadAll(rc)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("OpenCompressed() read %q, %v, want %q", got, err, tt.want)
+			}
+			if err := rc.Close(); err != nil {
+				t.Errorf("Close() failed: %v", err)
+			}
+		})
+	}
+
+	if _, err := OpenCompressed(write("broken.gz", []byte{0x1f, 0x8b, 0x00})); err == nil {
+		t.Error("OpenCompressed() of a broken gzip file returned no error")
+	}
+	if _, err := OpenCompressed(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("OpenCompressed() of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
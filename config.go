@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFormat is the encoding of a configuration file.
+type ConfigFormat string
+
+// Configuration formats supported by LoadConfig and SaveConfig.
+const (
+	ConfigJSON ConfigFormat = "json"
+	ConfigYAML ConfigFormat = "yaml"
+	ConfigTOML ConfigFormat = "toml"
+)
+
+var configExtensions = map[string]ConfigFormat{
+	".json": ConfigJSON,
+	".yaml": ConfigYAML,
+	".yml":  ConfigYAML,
+	".toml": ConfigTOML,
+}
+
+// ConfigOption configures LoadConfig and SaveConfig.
+type ConfigOption func(*configOptions)
+
+type configOptions struct {
+	format   ConfigFormat
+	saveOpts []SaveOption
+}
+
+// WithFormat uses the given format instead of detecting it from the file extension.
+func WithFormat(format ConfigFormat) ConfigOption {
+	return func(o *configOptions) {
+		o.format = format
+	}
+}
+
+// WithSaveOptions passes the given options on to SaveFileFunc when saving a configuration.
+func WithSaveOptions(opts ...SaveOption) ConfigOption {
+	return func(o *configOptions) {
+		o.saveOpts = append(o.saveOpts, opts...)
+	}
+}
+
+// ConfigFormatOf returns the configuration format matching the extension of the given file.
+func ConfigFormatOf(file string) (ConfigFormat, error) {
+	ext := strings.ToLower(filepath.Ext(file))
+	if format, ok := configExtensions[ext]; ok {
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported config format: %q", ext)
+}
+
+func newConfigOptions(file string, opts []ConfigOption) (*configOptions, error) {
+	o := &configOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.format == "" {
+		format, err := ConfigFormatOf(file)
+		if err != nil {
+			return nil, err
+		}
+		o.format = format
+	}
+	return o, nil
+}
+
+// LoadConfig decodes a configuration file as JSON, YAML or TOML, depending on its extension or the format given
+// by WithFormat.
+func LoadConfig(file string, v interface{}, opts ...ConfigOption) error {
+	o, err := newConfigOptions(file, opts)
+	if err != nil {
+		return err
+	}
+
+	switch o.format {
+	case ConfigJSON:
+		return LoadJSON(file, v)
+	case ConfigYAML:
+		return LoadYAML(file, v)
+	case ConfigTOML:
+		return LoadTOML(file, v)
+	}
+	return fmt.Errorf("unsupported config format: %q", o.format)
+}
+
+// SaveConfig safely writes a configuration file as JSON, YAML or TOML, depending on its extension or the format
+// given by WithFormat. JSON is written indented.
+func SaveConfig(file string, v interface{}, perm os.FileMode, opts ...ConfigOption) error {
+	o, err := newConfigOptions(file, opts)
+	if err != nil {
+		return err
+	}
+
+	switch o.format {
+	case ConfigJSON:
+		return SaveJSON(file, v, true, perm, o.saveOpts...)
+	case ConfigYAML:
+		return SaveYAML(file, v, perm, o.saveOpts...)
+	case ConfigTOML:
+		return SaveTOML(file, v, perm, o.saveOpts...)
+	}
+	return fmt.Errorf("unsupported config format: %q", o.format)
+}
@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigFormatOf(t *testing.T) {
+	tests := map[string]ConfigFormat{
+		"config.json":     ConfigJSON,
+		"config.YAML":     ConfigYAML,
+		"dir/config.yml":  ConfigYAML,
+		"config.toml":     ConfigTOML,
+		"config.ini":      "",
+		"config":          "",
+		"config.json.bak": "",
+	}
+
+	for file, want := range tests {
+		got, err := ConfigFormatOf(file)
+		if (err != nil) != (want == "") {
+			t.Errorf("ConfigFormatOf(%q) error = %v", file, err)
+		}
+		if got != want {
+			t.Errorf("ConfigFormatOf(%q) = %q, want %q", file, got, want)
+		}
+	}
+}
+
+func TestSaveLoadConfig(t *testing.T) {
+	type config struct {
+		Name  string   `json:"name" yaml:"name" toml:"name"`
+		Ports []int    `json:"ports" yaml:"ports" toml:"ports"`
+		Tags  []string `json:"tags" yaml:"tags" toml:"tags"`
+	}
+	want := config{Name: "server", Ports: []int{80, 443}, Tags: []string{"a", "b"}}
+	dir := t.TempDir()
+
+	tests := []struct {
+		file   string
+		opts   []ConfigOption
+		marker string
+	}{
+		{file: "config.json", marker: "\n  \"name\": \"server\""},
+		{file: "config.yaml", marker: "name: server"},
+		{file: "config.yml", marker: "name: server"},
+		{file: "config.toml", marker: `name = "server"`},
+		{file: "config.conf", opts: []ConfigOption{WithFormat(ConfigTOML)}, marker: `name = "server"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			file := filepath.Join(dir, tt.file)
+			if err := SaveConfig(file, want, 0644, tt.opts...); err != nil {
+				t.Fatalf("SaveConfig(%q) failed: %v", tt.file, err)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), tt.marker) {
+				t.Errorf("SaveConfig(%q) wrote %q, want it to contain %q", tt.file, data, tt.marker)
+			}
+
+			var got config
+			if err := LoadConfig(file, &got, tt.opts...); err != nil {
+				t.Fatalf("LoadConfig(%q) failed: %v", tt.file, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadConfig(%q) = %v, want %v", tt.file, got, want)
+			}
+		})
+	}
+
+	file := filepath.Join(dir, "backup.json")
+	for i := 0; i < 2; i++ {
+		if err := SaveConfig(file, want, 0644, WithSaveOptions(WithBackup(1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(file + ".bak"); err != nil {
+		t.Errorf("SaveConfig() with WithSaveOptions did not pass the options on: %v", err)
+	}
+
+	var got config
+	if err := LoadConfig(filepath.Join(dir, "config.ini"), &got); err == nil {
+		t.Error("LoadConfig() with unknown extension returned no error")
+	}
+	if err := SaveConfig(filepath.Join(dir, "config.ini"), want, 0644); err == nil {
+		t.Error("SaveConfig() with unknown extension returned no error")
+	}
+	if err := LoadConfig(filepath.Join(dir, "config.json"), &got, WithFormat("xml")); err == nil {
+		t.Error("LoadConfig() with unknown format returned no error")
+	}
+}
@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"context"
+	"time"
+)
+
+// ContextWithTimeoutString works like context.WithTimeout but takes the timeout as a string parsed by
+// ParseDuration, e.g. "90s" or "1m30s". A timeout that is not positive returns an already expired context.
+func ContextWithTimeoutString(parent context.Context, timeout string) (context.Context, context.CancelFunc, error) {
+	d, err := ParseDuration(timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(parent, d)
+	return ctx, cancel, nil
+}
+
+// SleepContext pauses for the given duration or until the context is canceled, in which case the context's error is
+// returned.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	return sleepUntil(ctx, time.Now().Add(d))
+}
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextWithTimeoutString(t *testing.T) {
+	ctx, cancel, err := ContextWithTimeoutString(context.Background(), "1m30s")
+	if err != nil {
+		t.Fatalf("ContextWithTimeoutString() error = %v", err)
+	}
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if left := time.Until(deadline); !ok || left > 90*time.Second || left < 80*time.Second {
+		t.Errorf("ContextWithTimeoutString() deadline in %v, want about 90s", left)
+	}
+
+	ctx, cancel, err = ContextWithTimeoutString(context.Background(), "0s")
+	if err != nil {
+		t.Fatalf("ContextWithTimeoutString() error = %v", err)
+	}
+	defer cancel()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("ContextWithTimeoutString(%q) error = %v, want %v", "0s", ctx.Err(), context.DeadlineExceeded)
+	}
+
+	if _, _, err := ContextWithTimeoutString(context.Background(), "soon"); err == nil {
+		t.Error("ContextWithTimeoutString() with invalid timeout succeeded")
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	start := time.Now()
+	if err := SleepContext(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatalf("SleepContext() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("SleepContext() returned after %v, want at least 10ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := SleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("SleepContext() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CopyOption configures CopyFile and CopyDir.
+type CopyOption func(*copyOptions)
+
+type copyOptions struct {
+	progress func(src string, copied, size int64)
+	saveOpts []SaveOption
+}
+
+// WithProgress calls fn while a file is copied with the number of bytes copied so far and the size of the file.
+func WithProgress(fn func(src string, copied, size int64)) CopyOption {
+	return func(o *copyOptions) {
+		o.progress = fn
+	}
+}
+
+// WithCopySaveOptions passes the given options on to SaveFileFunc when writing a copied file, e.g. WithSync.
+func WithCopySaveOptions(opts ...SaveOption) CopyOption {
+	return func(o *copyOptions) {
+		o.saveOpts = append(o.saveOpts, opts...)
+	}
+}
+
+func newCopyOptions(opts []CopyOption) *copyOptions {
+	o := &copyOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// CopyFile copies the regular file src to dst, preserving its permissions and modification time. Symlinks are
+// followed. The destination is replaced atomically, see SaveFileFunc.
+func CopyFile(src, dst string, opts ...CopyOption) error {
+	return copyFile(src, dst, newCopyOptions(opts))
+}
+
+func copyFile(src, dst string, o *copyOptions) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return fmt.Errorf("not a regular file: %q", src)
+	}
+
+	f := func(w io.Writer) error {
+		if o.progress != nil {
+			o.progress(src, 0, fi.Size())
+			w = &progressWriter{w: w, fn: func(n int64) { o.progress(src, n, fi.Size()) }}
+		}
+		_, err := io.Copy(w, in)
+		return err
+	}
+	if err = SaveFileFunc(dst, f, fi.Mode().Perm(), o.saveOpts...); err != nil {
+		return err
+	}
+	return os.Chtimes(dst, fi.ModTime(), fi.ModTime())
+}
+
+// progressWriter reports the total number of bytes written after each write.
+type progressWriter struct {
+	w  io.Writer
+	n  int64
+	fn func(n int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	p.fn(p.n)
+	return n, err
+}
+
+// CopyDir recursively copies the directory src to dst, which may already exist. Files are copied like CopyFile,
+// symlinks are recreated as they are and directories get the permissions and modification times of their source.
+// Other file types such as devices and sockets are skipped.
+func CopyDir(src, dst string, opts ...CopyOption) error {
+	o := newCopyOptions(opts)
+
+	absSrc, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	if absDst == absSrc || strings.HasPrefix(absDst, absSrc+string(filepath.Separator)) {
+		return fmt.Errorf("cannot copy directory %q into itself", src)
+	}
+
+	type dirInfo struct {
+		path string
+		info fs.FileInfo
+	}
+	var dirs []dirInfo
+
+	err = filepath.WalkDir(src, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case e.IsDir():
+			info, err := e.Info()
+			if err != nil {
+				return err
+			}
+			// Make sure the directory is writable while its content is copied, the final permissions are set
+			// afterwards.
+			if err = os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirInfo{path: target, info: info})
+		case e.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err = os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return os.Symlink(link, target)
+		case e.Type().IsRegular():
+			return copyFile(path, target, o)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Apply directory attributes bottom-up, as copying the content changes the modification time of a directory.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		d := dirs[i]
+		if err = os.Chmod(d.path, d.info.Mode().Perm()); err != nil {
+			return err
+		}
+		if err = os.Chtimes(d.path, d.info.ModTime(), d.info.ModTime()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MoveFile renames src to dst. If they are on different file systems, the regular file src is copied to dst like
+// CopyFile, synced to disk and removed afterwards.
+func MoveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !isCrossDevice(err) {
+		return err
+	}
+
+	if fi, serr := os.Lstat(src); serr != nil || !fi.Mode().IsRegular() {
+		return err
+	}
+	if err = CopyFile(src, dst, WithCopySaveOptions(WithSync())); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	content := strings.Repeat("0123456789", 10000)
+	if err := os.WriteFile(src, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chmod(src, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	var calls int
+	var last, size int64
+	dst := filepath.Join(dir, "dst.txt")
+	err := CopyFile(src, dst, WithProgress(func(name string, copied, total int64) {
+		if name != src {
+			t.Errorf("progress reported for %q, want %q", name, src)
+		}
+		if copied < last {
+			t.Errorf("progress went back from %d to %d", last, copied)
+		}
+		calls++
+		last, size = copied, total
+	}))
+	if err != nil {
+		t.Fatalf("CopyFile() failed: %v", err)
+	}
+
+	if data, err := os.ReadFile(dst); err != nil || string(data) != content {
+		t.Errorf("CopyFile() wrote %d bytes, %v, want %d", len(data), err, len(content))
+	}
+	fi, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && fi.Mode().Perm() != 0640 {
+		t.Errorf("CopyFile() set mode %v, want %v", fi.Mode().Perm(), os.FileMode(0640))
+	}
+	if !fi.ModTime().Equal(mtime) {
+		t.Errorf("CopyFile() set modification time %v, want %v", fi.ModTime(), mtime)
+	}
+	if calls < 2 || last != int64(len(content)) || size != int64(len(content)) {
+		t.Errorf("progress called %d times, last with %d of %d bytes", calls, last, size)
+	}
+
+	if err := CopyFile(dir, filepath.Join(dir, "copy")); err == nil {
+		t.Error("CopyFile() of a directory returned no error")
+	}
+	if err := CopyFile(filepath.Join(dir, "missing"), dst); !os.IsNotExist(err) {
+		t.Errorf("CopyFile() of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestCopyDir(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	makeTree(t, src, []string{"a.txt", "sub/b.txt", "sub/deep/c.txt"}, map[string]string{"sub/link": "../a.txt"})
+	if err := os.WriteFile(filepath.Join(src, "sub/b.txt"), []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chmod(filepath.Join(src, "sub"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(filepath.Join(src, "sub"), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	var copied []string
+	dst := filepath.Join(dir, "dst")
+	err := CopyDir(src, dst, WithProgress(func(name string, n, size int64) {
+		if n == size {
+			rel, _ := filepath.Rel(src, name)
+			copied = append(copied, filepath.ToSlash(rel))
+		}
+	}))
+	if err != nil {
+		t.Fatalf("CopyDir() failed: %v", err)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dst, "sub/b.txt")); err != nil || string(data) != "b" {
+		t.Errorf("CopyDir() copied %q, %v, want %q", data, err, "b")
+	}
+	if _, err := os.Stat(filepath.Join(dst, "sub/deep/c.txt")); err != nil {
+		t.Errorf("CopyDir() did not copy nested file: %v", err)
+	}
+	if link, err := os.Readlink(filepath.Join(dst, "sub/link")); err != nil || link != "../a.txt" {
+		t.Errorf("CopyDir() created link to %q, %v, want %q", link, err, "../a.txt")
+	}
+	fi, err := os.Stat(filepath.Join(dst, "sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && fi.Mode().Perm() != 0750 {
+		t.Errorf("CopyDir() set directory mode %v, want %v", fi.Mode().Perm(), os.FileMode(0750))
+	}
+	if !fi.ModTime().Equal(mtime) {
+		t.Errorf("CopyDir() set directory modification time %v, want %v", fi.ModTime(), mtime)
+	}
+	if len(copied) != 3 {
+		t.Errorf("progress reported completed files %q, want 3", copied)
+	}
+
+	// Copying again into the existing destination replaces its content
+	if err := CopyDir(src, dst); err != nil {
+		t.Errorf("CopyDir() into an existing directory failed: %v", err)
+	}
+	if err := CopyDir(src, filepath.Join(src, "sub", "copy")); err == nil {
+		t.Error("CopyDir() into itself returned no error")
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	if err := os.WriteFile(src, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "dst.txt")
+	if err := MoveFile(src, dst); err != nil {
+		t.Fatalf("MoveFile() failed: %v", err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("MoveFile() left the source in place")
+	}
+	if data, err := os.ReadFile(dst); err != nil || string(data) != "data" {
+		t.Errorf("MoveFile() moved %q, %v, want %q", data, err, "data")
+	}
+
+	if err := MoveFile(src, dst); !os.IsNotExist(err) {
+		t.Errorf("MoveFile() of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestMoveFileCrossDevice(t *testing.T) {
+	other, err := os.MkdirTemp("/dev/shm", "movefile")
+	if err != nil {
+		t.Skipf("no second file system available: %v", err)
+	}
+	defer os.RemoveAll(other)
+
+	src := filepath.Join(t.TempDir(), "src.txt")
+	if err := os.WriteFile(src, []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(other, "dst.txt")
+	if err := MoveFile(src, dst); err != nil {
+		t.Fatalf("MoveFile() failed: %v", err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("MoveFile() left the source in place")
+	}
+	fi, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("MoveFile() set mode %v, want %v", fi.Mode().Perm(), os.FileMode(0600))
+	}
+}
@@ -0,0 +1,73 @@
+package tools
+
+import "sort"
+
+// Count returns how often each value occurs.
+func Count[T comparable](values []T) map[T]int {
+	counts := map[T]int{}
+	for _, v := range values {
+		counts[v]++
+	}
+	return counts
+}
+
+// Counter counts occurrences of values. It is not safe for concurrent use. The zero value is an empty counter ready
+// to use.
+type Counter[T comparable] struct {
+	counts map[T]int
+	order  []T
+}
+
+// NewCounter returns a counter with the given values counted.
+func NewCounter[T comparable](values ...T) *Counter[T] {
+	c := &Counter[T]{}
+	c.Add(values...)
+	return c
+}
+
+// Add counts the given values.
+func (c *Counter[T]) Add(values ...T) {
+	if c.counts == nil {
+		c.counts = map[T]int{}
+	}
+	for _, v := range values {
+		if _, ok := c.counts[v]; !ok {
+			c.order = append(c.order, v)
+		}
+		c.counts[v]++
+	}
+}
+
+// Get returns how often the given value was counted.
+func (c *Counter[T]) Get(v T) int {
+	return c.counts[v]
+}
+
+// Len returns the number of distinct values.
+func (c *Counter[T]) Len() int {
+	return len(c.order)
+}
+
+// Total returns the number of counted values.
+func (c *Counter[T]) Total() int {
+	total := 0
+	for _, n := range c.counts {
+		total += n
+	}
+	return total
+}
+
+// MostCommon returns the n most common values with their counts, most common first. Values with equal counts are
+// ordered by their first occurrence. If n is negative, all values are returned.
+func (c *Counter[T]) MostCommon(n int) []Pair[T, int] {
+	result := make([]Pair[T, int], len(c.order))
+	for i, v := range c.order {
+		result[i] = Pair[T, int]{First: v, Second: c.counts[v]}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Second > result[j].Second })
+
+	if n >= 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
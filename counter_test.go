@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	got := Count([]string{"a", "b", "a", "c", "a"})
+	if want := map[string]int{"a": 3, "b": 1, "c": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Count() = %v, want %v", got, want)
+	}
+	if got := Count([]int(nil)); len(got) != 0 {
+		t.Errorf("Count(nil) = %v, want an empty map", got)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := NewCounter("x", "y", "x", "z", "y", "x", "w")
+
+	if c.Get("x") != 3 || c.Get("missing") != 0 {
+		t.Errorf("Get() = %d, %d, want 3, 0", c.Get("x"), c.Get("missing"))
+	}
+	if c.Len() != 4 || c.Total() != 7 {
+		t.Errorf("Len() = %d, Total() = %d, want 4, 7", c.Len(), c.Total())
+	}
+
+	tests := []struct {
+		n    int
+		want []Pair[string, int]
+	}{
+		{n: 2, want: []Pair[string, int]{{"x", 3}, {"y", 2}}},
+		{n: 3, want: []Pair[string, int]{{"x", 3}, {"y", 2}, {"z", 1}}},
+		{n: -1, want: []Pair[string, int]{{"x", 3}, {"y", 2}, {"z", 1}, {"w", 1}}},
+		{n: 10, want: []Pair[string, int]{{"x", 3}, {"y", 2}, {"z", 1}, {"w", 1}}},
+		{n: 0, want: []Pair[string, int]{}},
+	}
+	for _, tt := range tests {
+		if got := c.MostCommon(tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MostCommon(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+
+	var zero Counter[int]
+	if zero.Len() != 0 || zero.Total() != 0 || len(zero.MostCommon(-1)) != 0 {
+		t.Error("the zero value of Counter is not empty")
+	}
+	zero.Add(1, 1)
+	if zero.Get(1) != 2 {
+		t.Errorf("Get(1) on the zero value after Add = %d, want 2", zero.Get(1))
+	}
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	// Monday
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	date := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec  string
+		after time.Time
+		want  time.Time
+	}{
+		{spec: "*/15 * * * *", after: base, want: date(2024, 1, 15, 10, 45)},
+		{spec: "*/15 * * * *", after: base.Add(80 * time.Second), want: date(2024, 1, 15, 10, 45)},
+		{spec: "5/20 * * * *", after: base, want: date(2024, 1, 15, 10, 45)},
+		{spec: "0,10 * * * *", after: base, want: date(2024, 1, 15, 11, 0)},
+		{spec: "@hourly", after: base, want: date(2024, 1, 15, 11, 0)},
+		{spec: "@daily", after: base, want: date(2024, 1, 16, 0, 0)},
+		{spec: "@weekly", after: base, want: date(2024, 1, 21, 0, 0)},
+		{spec: "@monthly", after: base, want: date(2024, 2, 1, 0, 0)},
+		{spec: "@yearly", after: base, want: date(2025, 1, 1, 0, 0)},
+		{spec: "30 9 * * 1-5", after: base, want: date(2024, 1, 16, 9, 30)},
+		{spec: "30 9 * * mon-fri", after: date(2024, 1, 19, 10, 0), want: date(2024, 1, 22, 9, 30)},
+		{spec: "0 0 * * 7", after: base, want: date(2024, 1, 21, 0, 0)},
+		{spec: "0 0 * * SUN", after: base, want: date(2024, 1, 21, 0, 0)},
+		{spec: "0 12 * jun *", after: base, want: date(2024, 6, 1, 12, 0)},
+		{spec: "0 0 1 jan *", after: date(2024, 12, 31, 23, 59), want: date(2025, 1, 1, 0, 0)},
+		{spec: "59 23 31 12 *", after: date(2024, 12, 31, 23, 59), want: date(2025, 12, 31, 23, 59)},
+		{spec: "0 0 29 2 *", after: date(2024, 3, 1, 0, 0), want: date(2028, 2, 29, 0, 0)},
+		{spec: "0 0 31 * *", after: date(2024, 4, 1, 0, 0), want: date(2024, 5, 31, 0, 0)},
+		// Day of month and day of week are combined with OR if both are restricted
+		{spec: "0 0 13 * fri", after: base, want: date(2024, 1, 19, 0, 0)},
+		{spec: "0 0 16 * fri", after: base, want: date(2024, 1, 16, 0, 0)},
+		{spec: "0 0 30 2 *", after: base, want: time.Time{}},
+		{spec: "@every 90m", after: base, want: date(2024, 1, 15, 12, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseCron(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.spec, err)
+			}
+			if got := s.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("ParseCron(%q).Next(%v) = %v, want %v", tt.spec, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * foo *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"@every",
+		"@every -1m",
+		"@every abc",
+		"@sometimes",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", spec)
+		}
+	}
+}
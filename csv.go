@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOption configures LoadCSV and SaveCSV.
+type CSVOption func(*csvOptions)
+
+type csvOptions struct {
+	delimiter rune
+	noHeader  bool
+}
+
+// WithDelimiter sets the field delimiter, which defaults to a comma.
+func WithDelimiter(r rune) CSVOption {
+	return func(o *csvOptions) {
+		o.delimiter = r
+	}
+}
+
+// WithoutHeader treats the file as having no header line. Columns are then mapped to the struct fields in the order
+// of their declaration.
+func WithoutHeader() CSVOption {
+	return func(o *csvOptions) {
+		o.noHeader = true
+	}
+}
+
+func newCSVOptions(opts []CSVOption) *csvOptions {
+	o := &csvOptions{delimiter: ','}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// csvField is a struct field mapped to a CSV column.
+type csvField struct {
+	name  string
+	index int
+}
+
+// csvFields returns the exported fields of the struct type t. The column name is taken from the "csv" tag or
+// defaults to the field name. Fields tagged with "-" are skipped.
+func csvFields(t reflect.Type) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported CSV row type: %s", t)
+	}
+
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, csvField{name: name, index: i})
+	}
+	return fields, nil
+}
+
+// LoadCSV reads the rows of a CSV file into structs of type T. Columns are matched to the struct fields by the names
+// in the header line, see WithoutHeader, first exactly, then ignoring case. Unknown columns are ignored. Supported
+// field types are strings, booleans, numbers, time.Duration (see ParseDuration), time.Time (see ParseTime) and types
+// implementing encoding.TextUnmarshaler. Empty values leave fields at their zero value.
+func LoadCSV[T any](file string, opts ...CSVOption) ([]T, error) {
+	o := newCSVOptions(opts)
+
+	fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Close()
+
+	r := csv.NewReader(h)
+	r.Comma = o.delimiter
+	r.FieldsPerRecord = -1
+
+	// columns maps each column to a field, nil for unknown columns.
+	var columns []*csvField
+	if o.noHeader {
+		for i := range fields {
+			columns = append(columns, &fields[i])
+		}
+	} else {
+		header, err := r.Read()
+		if err == io.EOF {
+			return []T{}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		for _, name := range header {
+			columns = append(columns, matchCSVField(fields, strings.TrimSpace(name)))
+		}
+	}
+
+	rows := []T{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var row T
+		v := reflect.ValueOf(&row).Elem()
+		for i, value := range record {
+			if i >= len(columns) || columns[i] == nil || value == "" {
+				continue
+			}
+			if err := setCSVValue(v.Field(columns[i].index), value); err != nil {
+				line, _ := r.FieldPos(i)
+				return nil, fmt.Errorf("%s:%d: column %q: %w", file, line, columns[i].name, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+func matchCSVField(fields []csvField, name string) *csvField {
+	for i := range fields {
+		if fields[i].name == name {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func setCSVValue(v reflect.Value, s string) error {
+	switch v.Interface().(type) {
+	case time.Duration:
+		d, err := ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case time.Time:
+		t, err := ParseTime(s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// SaveCSV safely writes the given structs as rows of a CSV file, see SaveFileFunc. Unless WithoutHeader is given,
+// a header line with the column names is written first, see LoadCSV. Durations are formatted using FormatDuration,
+// times using RFC 3339 and types implementing encoding.TextMarshaler using their MarshalText method.
+func SaveCSV[T any](file string, rows []T, perm os.FileMode, opts ...CSVOption) error {
+	o := newCSVOptions(opts)
+
+	fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	f := func(w io.Writer) error {
+		cw := csv.NewWriter(w)
+		cw.Comma = o.delimiter
+
+		record := make([]string, len(fields))
+		if !o.noHeader {
+			for i, field := range fields {
+				record[i] = field.name
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			v := reflect.ValueOf(row)
+			for i, field := range fields {
+				s, err := formatCSVValue(v.Field(field.index))
+				if err != nil {
+					return fmt.Errorf("column %q: %w", field.name, err)
+				}
+				record[i] = s
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		return cw.Error()
+	}
+	return SaveFileFunc(file, f, perm)
+}
+
+func formatCSVValue(v reflect.Value) (string, error) {
+	switch x := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		return string(b), err
+	case time.Duration:
+		return FormatDuration(x), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported field type %s", v.Type())
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvRow struct {
+	Name     string        `csv:"name"`
+	Count    int           `csv:"count"`
+	Ratio    float64       `csv:"ratio"`
+	Active   bool          `csv:"active"`
+	Timeout  time.Duration `csv:"timeout"`
+	Created  time.Time     `csv:"created"`
+	Addr     net.IP        `csv:"addr"`
+	Size     uint16
+	Internal string `csv:"-"`
+	hidden   string
+}
+
+func TestSaveLoadCSV(t *testing.T) {
+	rows := []csvRow{
+		{
+			Name:    "first, with comma",
+			Count:   -3,
+			Ratio:   0.25,
+			Active:  true,
+			Timeout: 90 * time.Second,
+			Created: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			Addr:    net.ParseIP("192.0.2.1"),
+			Size:    512,
+		},
+		{Name: "second \"quoted\"", Count: 7, Created: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), Addr: net.ParseIP("::1")},
+	}
+
+	tests := []struct {
+		name   string
+		opts   []CSVOption
+		header string
+	}{
+		{name: "header", header: "name,count,ratio,active,timeout,created,addr,Size\n"},
+		{name: "delimiter", opts: []CSVOption{WithDelimiter(';')}, header: "name;count;ratio;active;timeout;created;addr;Size\n"},
+		{name: "without header", opts: []CSVOption{WithoutHeader()}, header: `"first, with comma",-3,`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "rows.csv")
+			if err := SaveCSV(file, rows, 0644, tt.opts...); err != nil {
+				t.Fatalf("SaveCSV() failed: %v", err)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(data), tt.header) {
+				t.Errorf("SaveCSV() wrote %q, want prefix %q", data, tt.header)
+			}
+
+			got, err := LoadCSV[csvRow](file, tt.opts...)
+			if err != nil {
+				t.Fatalf("LoadCSV() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Errorf("LoadCSV(SaveCSV(%v)) = %v", rows, got)
+			}
+		})
+	}
+}
+
+func TestLoadCSV(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		file := filepath.Join(dir, "rows.csv")
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	// Columns are matched by name, exactly or ignoring case, and unknown columns are ignored
+	file := write("unknown, COUNT ,name,size,Internal\nx,1,a,2,secret\ny,,b\n")
+	got, err := LoadCSV[csvRow](file)
+	if err != nil {
+		t.Fatalf("LoadCSV() failed: %v", err)
+	}
+	want := []csvRow{{Name: "a", Count: 1, Size: 2}, {Name: "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadCSV() = %v, want %v", got, want)
+	}
+
+	got, err = LoadCSV[csvRow](write(""))
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("LoadCSV() of an empty file = %v, %v, want no rows", got, err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "invalid number", content: "name,count\na,1\nb,x\n", wantErr: `:3: column "count"`},
+		{name: "invalid duration", content: "timeout\nsoon\n", wantErr: `column "timeout"`},
+		{name: "invalid time", content: "created\nyesterday\n", wantErr: `column "created"`},
+		{name: "invalid address", content: "addr\nnot-an-ip\n", wantErr: `column "addr"`},
+		{name: "overflow", content: "Size\n70000\n", wantErr: `column "Size"`},
+		{name: "malformed", content: "name\n\"unterminated\n", wantErr: "quote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadCSV[csvRow](write(tt.content)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadCSV() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadCSV[int](file); err == nil {
+		t.Error("LoadCSV() into a non-struct type returned no error")
+	}
+	if _, err := LoadCSV[csvRow](filepath.Join(dir, "missing.csv")); !os.IsNotExist(err) {
+		t.Errorf("LoadCSV() of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
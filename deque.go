@@ -0,0 +1,134 @@
+package tools
+
+// Deque is a double-ended queue backed by a ring buffer. With a fixed capacity, adding to a full deque drops the
+// element at the opposite end, which makes it suitable for keeping the most recent items. It is not safe for
+// concurrent use.
+type Deque[T any] struct {
+	buf   []T
+	head  int
+	size  int
+	fixed bool
+}
+
+// NewDeque returns an empty deque. If capacity is positive, the deque holds at most that many elements. Otherwise,
+// it grows as needed.
+func NewDeque[T any](capacity int) *Deque[T] {
+	if capacity > 0 {
+		return &Deque[T]{buf: make([]T, capacity), fixed: true}
+	}
+	return &Deque[T]{}
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// PushBack adds an element at the back. If the deque is full, the front element is dropped.
+func (d *Deque[T]) PushBack(v T) {
+	if d.full() {
+		if d.fixed {
+			d.buf[d.head] = v
+			d.head = d.index(1)
+			return
+		}
+		d.grow()
+	}
+	d.buf[d.index(d.size)] = v
+	d.size++
+}
+
+// PushFront adds an element at the front. If the deque is full, the back element is dropped.
+func (d *Deque[T]) PushFront(v T) {
+	if d.full() {
+		if d.fixed {
+			d.head = d.index(-1)
+			d.buf[d.head] = v
+			return
+		}
+		d.grow()
+	}
+	d.head = d.index(-1)
+	d.buf[d.head] = v
+	d.size++
+}
+
+// PopFront removes and returns the front element. The second return value is false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	return v, true
+}
+
+// PopBack removes and returns the back element. The second return value is false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	i := d.index(d.size - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	return v, true
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (T, bool) {
+	return d.At(0)
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (T, bool) {
+	return d.At(d.size - 1)
+}
+
+// At returns the element at the given position, counted from the front.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(i)], true
+}
+
+// Clear removes all elements.
+func (d *Deque[T]) Clear() {
+	var zero T
+	for i := range d.buf {
+		d.buf[i] = zero
+	}
+	d.head, d.size = 0, 0
+}
+
+// ToSlice returns the elements from front to back.
+func (d *Deque[T]) ToSlice() []T {
+	result := make([]T, d.size)
+	for i := range result {
+		result[i] = d.buf[d.index(i)]
+	}
+	return result
+}
+
+func (d *Deque[T]) full() bool {
+	return d.size == len(d.buf)
+}
+
+// index returns the buffer index of the element at the given offset from the head.
+func (d *Deque[T]) index(offset int) int {
+	n := len(d.buf)
+	return ((d.head+offset)%n + n) % n
+}
+
+// grow doubles the buffer size, moving the elements to the start of the new buffer.
+func (d *Deque[T]) grow() {
+	buf := make([]T, NextPow2(2*len(d.buf)))
+	copy(buf, d.ToSlice())
+	d.buf, d.head = buf, 0
+}
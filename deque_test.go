@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ops      func(d *Deque[int])
+		want     []int
+	}{
+		{
+			name: "push back",
+			ops: func(d *Deque[int]) {
+				for i := 1; i <= 5; i++ {
+					d.PushBack(i)
+				}
+			},
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "push front",
+			ops: func(d *Deque[int]) {
+				for i := 1; i <= 5; i++ {
+					d.PushFront(i)
+				}
+			},
+			want: []int{5, 4, 3, 2, 1},
+		},
+		{
+			name: "growing after wraparound",
+			ops: func(d *Deque[int]) {
+				for i := 1; i <= 4; i++ {
+					d.PushBack(i)
+				}
+				d.PopFront()
+				d.PopFront()
+				for i := 5; i <= 10; i++ {
+					d.PushBack(i)
+				}
+				d.PushFront(2)
+			},
+			want: []int{2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			name:     "fixed capacity drops front",
+			capacity: 3,
+			ops: func(d *Deque[int]) {
+				for i := 1; i <= 7; i++ {
+					d.PushBack(i)
+				}
+			},
+			want: []int{5, 6, 7},
+		},
+		{
+			name:     "fixed capacity drops back",
+			capacity: 3,
+			ops: func(d *Deque[int]) {
+				for i := 1; i <= 5; i++ {
+					d.PushFront(i)
+				}
+			},
+			want: []int{5, 4, 3},
+		},
+		{
+			name:     "fixed capacity wraparound with pops",
+			capacity: 4,
+			ops: func(d *Deque[int]) {
+				for i := 1; i <= 4; i++ {
+					d.PushBack(i)
+				}
+				d.PopFront()
+				d.PopBack()
+				d.PushBack(5)
+				d.PushBack(6)
+				d.PushBack(7)
+				d.PushFront(0)
+			},
+			want: []int{0, 3, 5, 6},
+		},
+		{
+			name:     "clear",
+			capacity: 2,
+			ops: func(d *Deque[int]) {
+				d.PushBack(1)
+				d.PushBack(2)
+				d.Clear()
+				d.PushFront(3)
+			},
+			want: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeque[int](tt.capacity)
+			tt.ops(d)
+			if got := d.ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ToSlice() = %v, want %v", got, tt.want)
+			}
+			if d.Len() != len(tt.want) {
+				t.Errorf("Len() = %d, want %d", d.Len(), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if v, ok := d.At(i); v != want || !ok {
+					t.Errorf("At(%d) = %d, %v, want %d, true", i, v, ok, want)
+				}
+			}
+			if front, ok := d.Front(); front != tt.want[0] || !ok {
+				t.Errorf("Front() = %d, %v, want %d, true", front, ok, tt.want[0])
+			}
+			if back, ok := d.Back(); back != tt.want[len(tt.want)-1] || !ok {
+				t.Errorf("Back() = %d, %v, want %d, true", back, ok, tt.want[len(tt.want)-1])
+			}
+		})
+	}
+}
+
+func TestDequePop(t *testing.T) {
+	var d Deque[string]
+	for _, v := range []string{"b", "c"} {
+		d.PushBack(v)
+	}
+	d.PushFront("a")
+
+	var got []string
+	for {
+		v, ok := d.PopBack()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+		if v, ok := d.PopFront(); ok {
+			got = append(got, v)
+		}
+	}
+	if want := []string{"c", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("popped %q, want %q", got, want)
+	}
+
+	if _, ok := d.PopFront(); ok {
+		t.Error("PopFront() on an empty deque succeeded")
+	}
+	if _, ok := d.Front(); ok {
+		t.Error("Front() on an empty deque succeeded")
+	}
+	if _, ok := d.At(0); ok {
+		t.Error("At(0) on an empty deque succeeded")
+	}
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Duration wraps time.Duration to encode it in the human-readable format of FormatDuration and to decode it using
+// ParseDuration. It implements encoding.TextMarshaler, encoding.TextUnmarshaler, json.Marshaler, json.Unmarshaler
+// and flag.Value, so it can be used in configuration structs and as a command line flag.
+type Duration time.Duration
+
+// String formats the duration using FormatDuration.
+func (d Duration) String() string {
+	return FormatDuration(time.Duration(d))
+}
+
+// Set parses the duration using ParseDuration.
+func (d *Duration) Set(s string) error {
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText formats the duration using FormatDuration.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses the duration using ParseDuration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
+
+// MarshalJSON encodes the duration as a JSON string formatted using FormatDuration.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string using ParseDuration. For compatibility with time.Duration, a JSON number is
+// taken as nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return d.Set(v)
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return fmt.Errorf("invalid duration: %s", data)
+		}
+		*d = Duration(n)
+		return nil
+	}
+	return fmt.Errorf("invalid duration: %s", data)
+}
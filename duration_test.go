@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+	"time"
+)
+
+func TestDurationText(t *testing.T) {
+	d := Duration(time.Minute + 30*time.Second)
+	if got := d.String(); got != "1m30s" {
+		t.Errorf("String() = %q, want %q", got, "1m30s")
+	}
+	text, err := d.MarshalText()
+	if err != nil || string(text) != "1m30s" {
+		t.Errorf("MarshalText() = %q, %v, want %q", text, err, "1m30s")
+	}
+
+	var got Duration
+	if err := got.UnmarshalText([]byte("2h 15m")); err != nil || got != Duration(135*time.Minute) {
+		t.Errorf("UnmarshalText() = %v, %v, want %v", got, err, Duration(135*time.Minute))
+	}
+	if err := got.UnmarshalText([]byte("soon")); err == nil {
+		t.Error("UnmarshalText() with invalid duration succeeded")
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	type config struct {
+		Timeout Duration `json:"timeout"`
+	}
+
+	data, err := json.Marshal(config{Timeout: Duration(36*time.Hour + 30*time.Minute + 15*time.Second)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"timeout":"1d12h30m15s"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	tests := []struct {
+		input   string
+		want    Duration
+		wantErr bool
+	}{
+		{input: `{"timeout":"1d12h"}`, want: Duration(36 * time.Hour)},
+		{input: `{"timeout":"P1DT1H"}`, want: Duration(25 * time.Hour)},
+		{input: `{"timeout":1500000000}`, want: Duration(1500 * time.Millisecond)},
+		{input: `{"timeout":null}`, want: Duration(time.Second)},
+		{input: `{"timeout":1.5}`, wantErr: true},
+		{input: `{"timeout":true}`, wantErr: true},
+		{input: `{"timeout":"soon"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			c := config{Timeout: Duration(time.Second)}
+			err := json.Unmarshal([]byte(tt.input), &c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && c.Timeout != tt.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, c.Timeout, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationFlag(t *testing.T) {
+	d := Duration(time.Second)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&d, "timeout", "timeout")
+
+	if err := fs.Parse([]string{"-timeout", "1w2d"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := Duration(9 * 24 * time.Hour); d != want {
+		t.Errorf("flag value = %v, want %v", d, want)
+	}
+}
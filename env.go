@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ciEnvVars lists environment variables set by common CI systems.
+var ciEnvVars = []string{
+	"GITHUB_ACTIONS", "GITLAB_CI", "CIRCLECI", "TRAVIS", "BUILDKITE", "DRONE", "JENKINS_URL", "TEAMCITY_VERSION",
+	"TF_BUILD", "BITBUCKET_BUILD_NUMBER", "CODEBUILD_BUILD_ID", "APPVEYOR",
+}
+
+// IsCI checks whether the process runs in a continuous integration environment.
+func IsCI() bool {
+	if IsOn(os.Getenv("CI"), false) {
+		return true
+	}
+	for _, name := range ciEnvVars {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsContainer checks whether the process runs inside a container like Docker, Podman or Kubernetes.
+func IsContainer() bool {
+	for _, file := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(file); err == nil {
+			return true
+		}
+	}
+	if os.Getenv("container") != "" || os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return true
+	}
+
+	data, err := os.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return false
+	}
+	s := string(data)
+	for _, marker := range []string{"docker", "kubepods", "containerd", "lxc", "libpod"} {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInteractive checks whether stdin is connected to a terminal.
+func IsInteractive() bool {
+	return isTerminal(os.Stdin)
+}
+
+// isTerminal checks whether the given file is a terminal. Other character devices like /dev/null are not.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
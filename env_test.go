@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsCI(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{name: "plain", want: false},
+		{name: "CI true", env: map[string]string{"CI": "true"}, want: true},
+		{name: "CI 1", env: map[string]string{"CI": "1"}, want: true},
+		{name: "CI false", env: map[string]string{"CI": "false"}, want: false},
+		{name: "GitHub Actions", env: map[string]string{"GITHUB_ACTIONS": "true"}, want: true},
+		{name: "Jenkins", env: map[string]string{"CI": "false", "JENKINS_URL": "http://ci"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CI", "")
+			for _, name := range ciEnvVars {
+				t.Setenv(name, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := IsCI(); got != tt.want {
+				t.Errorf("IsCI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	files := map[string]*os.File{"pipe": r, "regular file": file}
+	if null, err := os.Open(os.DevNull); err == nil {
+		defer null.Close()
+		files["null device"] = null
+	}
+
+	for name, f := range files {
+		if isTerminal(f) {
+			t.Errorf("isTerminal(%s) = true, want false", name)
+		}
+	}
+}
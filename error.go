@@ -2,14 +2,47 @@ package tools
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
-// Fail formats according to a format specifier, writes to stderr and exits with code 1.
+// FailOutput is the writer used by Fail, FailCode and FailErr to report errors.
+var FailOutput io.Writer = os.Stderr
+
+// Fail formats according to a format specifier, writes to FailOutput and exits with code 1.
 func Fail(format string, a ...interface{}) {
+	FailCode(1, format, a...)
+}
+
+// FailCode formats according to a format specifier, writes to FailOutput and exits with the given code.
+func FailCode(code int, format string, a ...interface{}) {
 	if len(a) > 0 {
 		format = fmt.Sprintf(format, a...)
 	}
-	fmt.Fprintln(os.Stderr, format)
-	Exit(1)
+	fmt.Fprintln(FailOutput, format)
+	Exit(code)
+}
+
+// FailErr writes the given error to FailOutput and exits with the given code. The error is formatted using %+v so
+// that errors carrying additional details, like stack traces, print them. A nil error exits without output.
+func FailErr(err error, code int) {
+	if err != nil {
+		fmt.Fprintf(FailOutput, "%+v\n", err)
+	}
+	Exit(code)
+}
+
+// SafeCall calls the given function and converts a panic into an error. If the recovered value is an error, it is
+// wrapped so that errors.Is and errors.As still work. Otherwise, the error returned by the function is passed through.
+func SafeCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("panic: %w", e)
+			} else {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}
+	}()
+	return fn()
 }
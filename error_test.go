@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSafeCall(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr string
+		wantIs  error
+	}{
+		{name: "success", fn: func() error { return nil }},
+		{name: "returned error", fn: func() error { return errFailed }, wantErr: "failed", wantIs: errFailed},
+		{name: "panic with string", fn: func() error { panic("boom") }, wantErr: "panic: boom"},
+		{name: "panic with error", fn: func() error { panic(errFailed) }, wantErr: "panic: failed", wantIs: errFailed},
+		{
+			name: "runtime error",
+			fn: func() error {
+				var m map[string]int
+				m["x"] = 1
+				return nil
+			},
+			wantErr: "panic: assignment to entry in nil map",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SafeCall(tt.fn)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("SafeCall() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("SafeCall() = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("SafeCall() = %v, does not wrap %v", err, tt.wantIs)
+			}
+		})
+	}
+}
+
+// captureExit replaces FailOutput and osExit for the duration of the test and returns the captured output and the
+// last exit code, which is -1 if osExit was not called.
+func captureExit(t *testing.T) (*strings.Builder, *int) {
+	t.Helper()
+	var out strings.Builder
+	code := -1
+
+	oldOutput, oldExit := FailOutput, osExit
+	FailOutput = &out
+	osExit = func(c int) { code = c }
+	t.Cleanup(func() {
+		FailOutput, osExit = oldOutput, oldExit
+	})
+	return &out, &code
+}
+
+func TestFail(t *testing.T) {
+	tests := []struct {
+		name     string
+		fail     func()
+		wantOut  string
+		wantCode int
+	}{
+		{name: "Fail", fail: func() { Fail("failed: %d", 3) }, wantOut: "failed: 3\n", wantCode: 1},
+		{name: "Fail without args", fail: func() { Fail("100%") }, wantOut: "100%\n", wantCode: 1},
+		{name: "FailCode", fail: func() { FailCode(3, "bad %s", "input") }, wantOut: "bad input\n", wantCode: 3},
+		{
+			name:     "FailErr",
+			fail:     func() { FailErr(fmt.Errorf("load: %w", errors.New("missing")), 4) },
+			wantOut:  "load: missing\n",
+			wantCode: 4,
+		},
+		{name: "FailErr nil", fail: func() { FailErr(nil, 0) }, wantOut: "", wantCode: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := captureExit(t)
+			tt.fail()
+			if out.String() != tt.wantOut {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOut)
+			}
+			if *code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", *code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestFailRunsExitHandlers(t *testing.T) {
+	_, code := captureExit(t)
+	ran := false
+	cancel := AtExit(func() { ran = true })
+	defer cancel()
+
+	FailCode(2, "failed")
+	if !ran {
+		t.Error("exit handler did not run")
+	}
+	if *code != 2 {
+		t.Errorf("exit code = %d, want 2", *code)
+	}
+}
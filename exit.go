@@ -2,7 +2,9 @@ package tools
 
 import (
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 )
 
 type exitFunc struct {
@@ -14,6 +16,9 @@ var exitFuncs = []*exitFunc{}
 var exitFuncsMutex sync.Mutex
 var nextExitID int64
 
+// osExit is the function used by Exit to terminate the process.
+var osExit = os.Exit
+
 // AtExit registers the given function to be run when Exit() is called. It returns a cancel
 // function that allows to remove the exit function.
 func AtExit(f func()) (cancel func()) {
@@ -51,5 +56,56 @@ func Exit(code int) {
 	for i := len(exitFuncs) - 1; i >= 0; i-- {
 		exitFuncs[i].f()
 	}
-	os.Exit(code)
+	osExit(code)
+}
+
+// HandleSignals calls Exit with the given code when one of the given signals is received. If no signals are given,
+// os.Interrupt and SIGTERM are handled. It returns a cancel function that stops handling the signals.
+func HandleSignals(code int, sigs ...os.Signal) (cancel func()) {
+	return handleSignals(code, false, sigs)
+}
+
+// HandleSignalsForceSecond works like HandleSignals, but if a second signal is received while the exit functions are
+// still running, the process exits immediately without waiting for them to finish.
+func HandleSignalsForceSecond(code int, sigs ...os.Signal) (cancel func()) {
+	return handleSignals(code, true, sigs)
+}
+
+func handleSignals(code int, force bool, sigs []os.Signal) (cancel func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+	go watchSignals(ch, done, code, force)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// watchSignals runs Exit on the first signal received from ch. If force is set, a second signal exits immediately.
+func watchSignals(ch <-chan os.Signal, done <-chan struct{}, code int, force bool) {
+	exiting := false
+	for {
+		select {
+		case <-done:
+			return
+		case <-ch:
+		}
+
+		switch {
+		case !exiting:
+			exiting = true
+			go Exit(code)
+		case force:
+			osExit(code)
+		}
+	}
 }
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWatchSignals(t *testing.T) {
+	tests := []struct {
+		name  string
+		force bool
+	}{
+		{name: "force second", force: true},
+		{name: "wait for exit functions", force: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exits := make(chan int, 2)
+			oldExit := osExit
+			osExit = func(code int) { exits <- code }
+			t.Cleanup(func() { osExit = oldExit })
+
+			started, release := make(chan struct{}), make(chan struct{})
+			cancel := AtExit(func() {
+				close(started)
+				<-release
+			})
+			t.Cleanup(cancel)
+
+			ch := make(chan os.Signal)
+			done := make(chan struct{})
+			stopped := make(chan struct{})
+			go func() {
+				watchSignals(ch, done, 3, tt.force)
+				close(stopped)
+			}()
+
+			ch <- syscall.SIGTERM
+			<-started
+			ch <- syscall.SIGTERM
+
+			if tt.force {
+				select {
+				case code := <-exits:
+					if code != 3 {
+						t.Errorf("second signal exited with code %d, want 3", code)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("second signal did not exit while the exit functions were running")
+				}
+			} else {
+				select {
+				case code := <-exits:
+					t.Errorf("second signal exited with code %d before the exit functions finished", code)
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+
+			close(release)
+			select {
+			case code := <-exits:
+				if code != 3 {
+					t.Errorf("Exit exited with code %d, want 3", code)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Exit did not exit after the exit functions finished")
+			}
+
+			close(done)
+			<-stopped
+		})
+	}
+}
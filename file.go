@@ -1,16 +1,30 @@
 package tools
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 )
 
 // ResolvePath resolves the given path. If it exist, it is returned. If it does not exist and does not contain
 // any wildcard characters, os.ErrNotExist is returned. Otherwise, the result of filepath.Glob is returned.
-// Unless the base of the glob pattern starts with a dot, entries stating with a dot are ignored.
+// Unless the base of the glob pattern starts with a dot, entries stating with a dot are ignored. Patterns containing
+// "**" as a path element are resolved recursively using ResolvePathRecursive.
 func ResolvePath(path string) ([]string, error) {
 	path = filepath.Clean(path)
 
@@ -20,6 +34,10 @@ func ResolvePath(path string) ([]string, error) {
 		return nil, os.ErrNotExist
 	}
 
+	if isRecursivePattern(path) {
+		return ResolvePathRecursive(path)
+	}
+
 	matches, err := filepath.Glob(path)
 	if err != nil {
 		return nil, err
@@ -55,7 +73,392 @@ func ResolveFiles(path string) ([]string, error) {
 	return files, nil
 }
 
-func SaveFileFunc(file string, f func(w io.Writer) error, perm os.FileMode) error {
+// ResolveDirs resolves the given path to all existing directories, see ResolvePath.
+func ResolveDirs(path string) ([]string, error) {
+	paths, err := ResolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{}
+	for _, path = range paths {
+		if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+			dirs = append(dirs, path)
+		}
+	}
+	return dirs, nil
+}
+
+// ResolveWith resolves the given path like ResolvePathRecursive and returns the paths accepted by all filters given
+// as options, e.g. ResolveWith("logs/**/*", OnlyFiles(), WithExtensions(".log", ".gz")).
+func ResolveWith(path string, opts ...ResolveOption) ([]string, error) {
+	o := &resolveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	paths, err := ResolvePathRecursive(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return Select(paths, o.accept), nil
+}
+
+// NormalizePath returns the canonical form of the given path: cleaned, absolute and with all symlinks resolved, so
+// that different references to the same file compare equal. If the path does not exist, it is only cleaned and made
+// absolute.
+func NormalizePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	real, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return abs, nil
+		}
+		return "", err
+	}
+	return real, nil
+}
+
+// ResolveOption configures how paths are resolved.
+type ResolveOption func(*resolveOptions)
+
+type resolveOptions struct {
+	noFollow bool
+	types    resolveType
+	filters  []func(path string, info os.FileInfo) bool
+}
+
+// resolveType is a bit set of the entry types accepted by ResolveWith.
+type resolveType uint8
+
+const (
+	resolveFiles resolveType = 1 << iota
+	resolveDirs
+	resolveSymlinks
+)
+
+// NoFollowSymlinks makes path resolution use os.Lstat instead of os.Stat, so symlinks are reported as they are and
+// symlinked directories are not descended into.
+func NoFollowSymlinks() ResolveOption {
+	return func(o *resolveOptions) {
+		o.noFollow = true
+	}
+}
+
+// OnlyFiles makes ResolveWith return regular files. Combined with OnlyDirs or OnlySymlinks, entries of any of the
+// given types are returned.
+func OnlyFiles() ResolveOption {
+	return func(o *resolveOptions) {
+		o.types |= resolveFiles
+	}
+}
+
+// OnlyDirs makes ResolveWith return directories, see OnlyFiles.
+func OnlyDirs() ResolveOption {
+	return func(o *resolveOptions) {
+		o.types |= resolveDirs
+	}
+}
+
+// OnlySymlinks makes ResolveWith return symlinks, see OnlyFiles.
+func OnlySymlinks() ResolveOption {
+	return func(o *resolveOptions) {
+		o.types |= resolveSymlinks
+	}
+}
+
+// WithExtensions makes ResolveWith return only entries with one of the given extensions. The comparison ignores
+// case and the leading dot is optional.
+func WithExtensions(exts ...string) ResolveOption {
+	m := map[string]bool{}
+	for _, ext := range exts {
+		m["."+strings.ToLower(strings.TrimPrefix(ext, "."))] = true
+	}
+	return WithFilter(func(path string, _ os.FileInfo) bool {
+		return m[strings.ToLower(filepath.Ext(path))]
+	})
+}
+
+// WithSize makes ResolveWith return only entries with a size between min and max bytes, inclusive. A max of 0 or
+// less means there is no upper limit.
+func WithSize(min, max int64) ResolveOption {
+	return WithFilter(func(_ string, info os.FileInfo) bool {
+		return info.Size() >= min && (max <= 0 || info.Size() <= max)
+	})
+}
+
+// WithModTime makes ResolveWith return only entries last modified in the range [after, before). A zero time means
+// there is no limit on that side.
+func WithModTime(after, before time.Time) ResolveOption {
+	return WithFilter(func(_ string, info os.FileInfo) bool {
+		mtime := info.ModTime()
+		return (after.IsZero() || !mtime.Before(after)) && (before.IsZero() || mtime.Before(before))
+	})
+}
+
+// WithFilter makes ResolveWith return only entries for which fn returns true. The file info is obtained as for
+// path resolution, see NoFollowSymlinks.
+func WithFilter(fn func(path string, info os.FileInfo) bool) ResolveOption {
+	return func(o *resolveOptions) {
+		o.filters = append(o.filters, fn)
+	}
+}
+
+// ResolvePathRecursive works like ResolvePath but additionally supports "**" as a path element matching zero or more
+// directories, e.g. "logs/**/*.log". Unless the base of the pattern starts with a dot, entries starting with a dot are
+// neither matched nor descended into. Symlinks are followed unless NoFollowSymlinks is given. The result is sorted and
+// free of duplicates.
+func ResolvePathRecursive(pattern string, opts ...ResolveOption) ([]string, error) {
+	o := &resolveOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	pattern = filepath.Clean(pattern)
+
+	if _, err := o.stat(pattern); err == nil {
+		return []string{pattern}, nil
+	} else if !strings.ContainsAny(pattern, "*?[") {
+		return nil, os.ErrNotExist
+	}
+
+	// Validate the pattern up front, like filepath.Glob does.
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	sep := string(filepath.Separator)
+	vol := filepath.VolumeName(pattern)
+	rest := pattern[len(vol):]
+	root := vol
+	if strings.HasPrefix(rest, sep) {
+		root += sep
+		rest = rest[1:]
+	}
+
+	r := &recursiveResolver{
+		opts:    o,
+		skipDot: !strings.HasPrefix(filepath.Base(pattern), "."),
+		found:   map[string]bool{},
+		visited: map[string]bool{},
+	}
+	r.match(root, strings.Split(rest, sep))
+
+	paths := Keys(r.found)
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// isRecursivePattern reports whether the pattern contains "**" as a path element.
+func isRecursivePattern(pattern string) bool {
+	for _, elem := range strings.Split(pattern, string(filepath.Separator)) {
+		if elem == "**" {
+			return true
+		}
+	}
+	return false
+}
+
+// accept reports whether path passes the type restrictions and filters.
+func (o *resolveOptions) accept(path string) bool {
+	info, err := o.stat(path)
+	if err != nil {
+		return false
+	}
+
+	if o.types != 0 {
+		var t resolveType
+		switch {
+		case info.Mode().IsRegular():
+			t = resolveFiles
+		case info.IsDir():
+			t = resolveDirs
+		}
+		if o.types&resolveSymlinks != 0 {
+			if linfo, err := os.Lstat(path); err == nil && linfo.Mode()&os.ModeSymlink != 0 {
+				t |= resolveSymlinks
+			}
+		}
+		if o.types&t == 0 {
+			return false
+		}
+	}
+
+	for _, fn := range o.filters {
+		if !fn(path, info) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *resolveOptions) stat(path string) (os.FileInfo, error) {
+	if o.noFollow {
+		return os.Lstat(path)
+	}
+	return os.Stat(path)
+}
+
+type recursiveResolver struct {
+	opts    *resolveOptions
+	skipDot bool
+	found   map[string]bool
+	visited map[string]bool
+}
+
+// match resolves the remaining pattern elements relative to dir.
+func (r *recursiveResolver) match(dir string, elems []string) {
+	if len(elems) == 0 {
+		if _, err := r.opts.stat(dir); err == nil {
+			r.found[dir] = true
+		}
+		return
+	}
+
+	elem := elems[0]
+	switch {
+	case elem == "**":
+		if !r.enter(dir, elems) {
+			return
+		}
+
+		// Match zero directories first, then descend into each subdirectory keeping the "**" element.
+		r.match(dir, elems[1:])
+		for _, e := range r.readDir(dir) {
+			path := joinPath(dir, e.Name())
+			if r.isDir(path, e) {
+				r.match(path, elems)
+			}
+		}
+	case !strings.ContainsAny(elem, "*?["):
+		r.match(joinPath(dir, elem), elems[1:])
+	default:
+		for _, e := range r.readDir(dir) {
+			if ok, _ := filepath.Match(elem, e.Name()); ok {
+				r.match(joinPath(dir, e.Name()), elems[1:])
+			}
+		}
+	}
+}
+
+// enter reports whether dir has not been visited yet by the "**" element starting elems and marks it as visited.
+// This protects against cycles caused by symlinks, while patterns with several "**" elements may still visit a
+// directory once per element.
+func (r *recursiveResolver) enter(dir string, elems []string) bool {
+	key := dirPath(dir)
+	if abs, err := filepath.Abs(key); err == nil {
+		key = abs
+	}
+	if !r.opts.noFollow {
+		if real, err := filepath.EvalSymlinks(key); err == nil {
+			key = real
+		}
+	}
+	key += "\x00" + strconv.Itoa(len(elems))
+	if r.visited[key] {
+		return false
+	}
+	r.visited[key] = true
+	return true
+}
+
+func (r *recursiveResolver) readDir(dir string) []os.DirEntry {
+	entries, err := os.ReadDir(dirPath(dir))
+	if err != nil {
+		return nil
+	}
+	if !r.skipDot {
+		return entries
+	}
+	return Select(entries, func(e os.DirEntry) bool { return !strings.HasPrefix(e.Name(), ".") })
+}
+
+func (r *recursiveResolver) isDir(path string, e os.DirEntry) bool {
+	if r.opts.noFollow || e.Type()&os.ModeSymlink == 0 {
+		return e.IsDir()
+	}
+	stat, err := os.Stat(path)
+	return err == nil && stat.IsDir()
+}
+
+// dirPath returns the directory to operate on, using the current directory for an empty path.
+func dirPath(dir string) string {
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+func joinPath(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return filepath.Join(dir, name)
+}
+
+// SaveOption configures how files are written by SaveFileFunc and the functions built on it.
+type SaveOption func(*saveOptions)
+
+type saveOptions struct {
+	sync         bool
+	preserveMode bool
+	backups      int
+	lock         bool
+	compress     bool
+}
+
+// WithSync flushes the written data to stable storage before the file is moved into place and syncs the containing
+// directory afterwards, so the new content survives a crash.
+func WithSync() SaveOption {
+	return func(o *saveOptions) {
+		o.sync = true
+	}
+}
+
+// WithPreserveMode keeps the permissions and, if possible, the owner of an existing destination file instead of
+// applying the given permissions.
+func WithPreserveMode() SaveOption {
+	return func(o *saveOptions) {
+		o.preserveMode = true
+	}
+}
+
+// WithBackup keeps up to n previous versions of the destination file. The most recent one is named like the file
+// with the suffix ".bak", older ones are numbered, e.g. "config.yaml.bak.1" for the second most recent version.
+func WithBackup(n int) SaveOption {
+	return func(o *saveOptions) {
+		o.backups = n
+	}
+}
+
+// SaveFileFunc safely writes a file by calling f with a temporary file in the same directory and moving it over the
+// destination file once f returns successfully. The file gets the permissions perm, the umask is not applied. If the
+// directory does not exist, it is created, but not its parents.
+func SaveFileFunc(file string, f func(w io.Writer) error, perm os.FileMode, opts ...SaveOption) error {
+	var o saveOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.compress {
+		write := f
+		f = func(w io.Writer) error {
+			cw, err := newCompressWriter(w, file)
+			if err != nil {
+				return err
+			}
+			if err = write(cw); err != nil {
+				cw.Close()
+				return err
+			}
+			return cw.Close()
+		}
+	}
+
 	dir := filepath.Dir(file)
 	tmp, err := os.CreateTemp(dir, "."+filepath.Base(file))
 	if err != nil {
@@ -75,36 +478,220 @@ func SaveFileFunc(file string, f func(w io.Writer) error, perm os.FileMode) erro
 		}
 	}
 
+	if o.lock {
+		unlock, err := LockFile(file + ".lock")
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		defer unlock()
+	}
+
+	if err = applyFileMode(tmp.Name(), file, perm, o.preserveMode); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
 	if err = f(tmp); err != nil {
 		tmp.Close()
 		os.Remove(tmp.Name())
 		return err
 	}
 
+	if o.sync {
+		if err = tmp.Sync(); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+
 	if err = tmp.Close(); err != nil {
 		os.Remove(tmp.Name())
 		return err
 	}
 
+	if o.backups > 0 {
+		if err = rotateBackups(file, o.backups); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+
 	if err = os.Rename(tmp.Name(), file); err != nil {
 		os.Remove(tmp.Name())
+		return err
 	}
-	return err
+
+	if o.sync {
+		return syncDir(dir)
+	}
+	return nil
+}
+
+// applyFileMode sets the permissions of the temporary file tmp to perm or, if preserve is set and the destination
+// file exists, to the permissions and owner of the destination.
+func applyFileMode(tmp, file string, perm os.FileMode, preserve bool) error {
+	if preserve {
+		fi, err := os.Stat(file)
+		if err == nil {
+			if err := os.Chmod(tmp, fi.Mode().Perm()); err != nil {
+				return err
+			}
+			return copyOwner(tmp, fi)
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Chmod(tmp, perm)
+}
+
+// rotateBackups shifts the existing backups of file and turns the current version of file into the most recent
+// backup, keeping at most n backups.
+func rotateBackups(file string, n int) error {
+	if _, err := os.Lstat(file); os.IsNotExist(err) {
+		return nil
+	}
+
+	name := func(i int) string {
+		if i == 0 {
+			return file + ".bak"
+		}
+		return file + ".bak." + strconv.Itoa(i)
+	}
+
+	if err := os.Remove(name(n - 1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := n - 1; i > 0; i-- {
+		if err := os.Rename(name(i-1), name(i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	// A hard link keeps the current version while the file itself is replaced. Fall back to a copy if the file
+	// system does not support it.
+	if err := os.Link(file, name(0)); err == nil {
+		return nil
+	}
+	return copyFileContent(file, name(0))
+}
+
+// copyFileContent copies the content and permissions of src to dst, which must not exist.
+func copyFileContent(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+// syncDir flushes the directory entries of dir to stable storage. This is not supported on Windows, where it is a
+// no-op.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	h, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	return h.Sync()
+}
+
+// TruncateFileToLines keeps only the last keepLast lines of the given file. The file is rewritten safely, see
+// SaveFileFunc. If the file has no more than keepLast lines, it is left untouched.
+func TruncateFileToLines(file string, keepLast int, perm os.FileMode) error {
+	h, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	offset, err := lastLinesOffset(h, keepLast)
+	if err != nil || offset == 0 {
+		return err
+	}
+
+	f := func(w io.Writer) error {
+		if _, err := h.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		_, err := io.Copy(w, h)
+		return err
+	}
+	return SaveFileFunc(file, f, perm)
+}
+
+// lastLinesOffset returns the offset at which the last n lines of the file start. The file is read backwards in
+// chunks so that only the relevant part needs to be read.
+func lastLinesOffset(h *os.File, n int) (int64, error) {
+	stat, err := h.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := stat.Size()
+	if n <= 0 {
+		return size, nil
+	}
+
+	buf := make([]byte, 32*1024)
+	end := size
+	for end > 0 {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		chunk := buf[:end-start]
+		if _, err := h.ReadAt(chunk, start); err != nil {
+			return 0, err
+		}
+		for i := len(chunk) - 1; i >= 0; i-- {
+			// A newline terminating the last line does not start another line
+			if chunk[i] != '\n' || start+int64(i) == size-1 {
+				continue
+			}
+			if n--; n == 0 {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
 }
 
 // SaveFile safely writes data to a file by writing it to a temporary file first before moving it over the
 // destination file to ensure atomicity.
-func SaveFile(file string, data []byte, perm os.FileMode) error {
+func SaveFile(file string, data []byte, perm os.FileMode, opts ...SaveOption) error {
 	f := func(w io.Writer) error {
 		_, err := w.Write(data)
 		return err
 	}
-	return SaveFileFunc(file, f, perm)
+	return SaveFileFunc(file, f, perm, opts...)
 }
 
 // SaveJSON safely writes JSON encoded data to a file by encoding the given value to a temporary file first
 // before moving it over the destination file. This should ensure atomicity.
-func SaveJSON(file string, v interface{}, indented bool, perm os.FileMode) error {
+func SaveJSON(file string, v interface{}, indented bool, perm os.FileMode, opts ...SaveOption) error {
 	f := func(w io.Writer) error {
 		enc := json.NewEncoder(w)
 		if indented {
@@ -112,7 +699,7 @@ func SaveJSON(file string, v interface{}, indented bool, perm os.FileMode) error
 		}
 		return enc.Encode(v)
 	}
-	return SaveFileFunc(file, f, perm)
+	return SaveFileFunc(file, f, perm, opts...)
 }
 
 // LoadJSON decodes JSON read from the given file.
@@ -124,3 +711,194 @@ func LoadJSON(file string, v interface{}) error {
 	defer h.Close()
 	return json.NewDecoder(h).Decode(v)
 }
+
+// SaveYAML safely writes YAML encoded data to a file by encoding the given value to a temporary file first
+// before moving it over the destination file, see SaveFileFunc.
+func SaveYAML(file string, v interface{}, perm os.FileMode, opts ...SaveOption) error {
+	f := func(w io.Writer) error {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return SaveFileFunc(file, f, perm, opts...)
+}
+
+// SaveTOML safely writes TOML encoded data to a file by encoding the given value to a temporary file first
+// before moving it over the destination file, see SaveFileFunc.
+func SaveTOML(file string, v interface{}, perm os.FileMode, opts ...SaveOption) error {
+	f := func(w io.Writer) error {
+		return toml.NewEncoder(w).Encode(v)
+	}
+	return SaveFileFunc(file, f, perm, opts...)
+}
+
+// LoadTOML decodes TOML read from the given file.
+func LoadTOML(file string, v interface{}) error {
+	h, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	_, err = toml.NewDecoder(h).Decode(v)
+	return err
+}
+
+// LoadYAML decodes YAML read from the given file. An empty file leaves v unchanged.
+func LoadYAML(file string, v interface{}) error {
+	h, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	if err = yaml.NewDecoder(h).Decode(v); err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// LoadJSONStream decodes a sequence of JSON values read from the given file and calls fn for each of them. This
+// supports both newline-delimited JSON and concatenated JSON values. The first decode error or the first error
+// returned by fn is returned.
+func LoadJSONStream[T any](file string, fn func(T) error) error {
+	h, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	dec := json.NewDecoder(h)
+	for {
+		var v T
+		if err := dec.Decode(&v); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+}
+
+// SaveJSONStream safely writes the given values as newline-delimited JSON to a file, see SaveFileFunc.
+func SaveJSONStream[T any](file string, values []T, perm os.FileMode, opts ...SaveOption) error {
+	f := func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		for _, v := range values {
+			if err := enc.Encode(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return SaveFileFunc(file, f, perm, opts...)
+}
+
+// ReadJSONLines returns a sequence of the values read from a JSON Lines file, where each non-empty line holds one
+// JSON value. The file is read anew each time the sequence is iterated. Iteration stops at the first error, which
+// is returned by the error function afterwards.
+func ReadJSONLines[T any](file string) (Seq[T], func() error) {
+	var err error
+	seq := func(yield func(T) bool) {
+		err = nil
+
+		h, oerr := os.Open(file)
+		if oerr != nil {
+			err = oerr
+			return
+		}
+		defer h.Close()
+
+		r := bufio.NewReader(h)
+		for n := 1; ; n++ {
+			line, rerr := r.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				var v T
+				if jerr := json.Unmarshal(line, &v); jerr != nil {
+					err = fmt.Errorf("%s:%d: %w", file, n, jerr)
+					return
+				}
+				if !yield(v) {
+					return
+				}
+			}
+			if rerr == io.EOF {
+				return
+			} else if rerr != nil {
+				err = rerr
+				return
+			}
+		}
+	}
+	return seq, func() error { return err }
+}
+
+// AppendJSONLine appends the given value as a line of JSON to a JSON Lines file, creating it with the permissions
+// perm (before umask) if necessary.
+func AppendJSONLine(file string, v interface{}, perm os.FileMode) error {
+	return AppendJSONLines(file, []interface{}{v}, perm)
+}
+
+// AppendJSONLines appends the given values as lines of JSON to a JSON Lines file, creating it with the permissions
+// perm (before umask) if necessary. All values are encoded before the file is opened, so an encoding error leaves
+// the file unchanged. The file is locked while the lines are written, so appenders using this function do not
+// interleave their lines.
+func AppendJSONLines[T any](file string, values []T, perm os.FileMode) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+
+	h, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perm)
+	if err != nil {
+		return err
+	}
+	if err = lockFile(h, true); err != nil {
+		h.Close()
+		return err
+	}
+	if _, err = h.Write(buf.Bytes()); err != nil {
+		h.Close()
+		return err
+	}
+	// Closing the file releases the lock
+	return h.Close()
+}
+
+// ReadTextUTF8 reads a text file and returns its content as UTF-8. A byte order mark for UTF-8, UTF-16LE or UTF-16BE
+// is detected and removed, and UTF-16 content is converted to UTF-8. Files without a byte order mark are returned
+// unchanged.
+func ReadTextUTF8(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:]), nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		order = binary.LittleEndian
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		order = binary.BigEndian
+	default:
+		return string(data), nil
+	}
+
+	data = data[2:]
+	if len(data)%2 != 0 {
+		return "", errors.New("invalid UTF-16 data: odd number of bytes")
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package tools
+
+import "os"
+
+// copyOwner is a no-op on systems without Unix file ownership.
+func copyOwner(name string, fi os.FileInfo) error {
+	return nil
+}
+
+// isCrossDevice reports whether err indicates a rename across file systems.
+func isCrossDevice(err error) bool {
+	return false
+}
@@ -0,0 +1,797 @@
+package tools
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+// makeTree creates the given files below dir and the given symlinks, mapping link names to their targets.
+func makeTree(t *testing.T, dir string, files []string, links map[string]string) {
+	t.Helper()
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, link)); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+}
+
+// chdir changes the working directory for the duration of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestResolvePathRecursive(t *testing.T) {
+	dir := t.TempDir()
+	makeTree(t, dir,
+		[]string{"logs/a.log", "logs/.hidden.log", "logs/sub/b.log", "logs/sub/deep/c.log", "logs/sub/d.txt"},
+		map[string]string{
+			"logs/sub/up":     "..",
+			"logs/sub/loop":   filepath.Join(dir, "logs"),
+			"logs/broken.log": "missing.log",
+		},
+	)
+	chdir(t, dir)
+
+	abs := func(paths ...string) []string {
+		for i, p := range paths {
+			paths[i] = filepath.Join(dir, p)
+		}
+		return paths
+	}
+	rel := func(paths ...string) []string {
+		for i, p := range paths {
+			paths[i] = filepath.FromSlash(p)
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		opts    []ResolveOption
+		want    []string
+	}{
+		{
+			name:    "nested",
+			pattern: "logs/**/*.log",
+			want:    rel("logs/a.log", "logs/sub/b.log", "logs/sub/deep/c.log"),
+		},
+		{
+			name:    "nested absolute",
+			pattern: filepath.Join(dir, "logs/**/*.log"),
+			want:    abs("logs/a.log", "logs/sub/b.log", "logs/sub/deep/c.log"),
+		},
+		{
+			name:    "repeated globstar",
+			pattern: "logs/**/**/*.log",
+			want:    rel("logs/a.log", "logs/sub/b.log", "logs/sub/deep/c.log"),
+		},
+		{
+			name:    "symlink loop relative",
+			pattern: "**/c.log",
+			want:    rel("logs/sub/deep/c.log"),
+		},
+		{
+			name:    "symlink loop absolute",
+			pattern: filepath.Join(dir, "**/c.log"),
+			want:    abs("logs/sub/deep/c.log"),
+		},
+		{
+			name:    "dot-prefixed base",
+			pattern: "logs/**/.*.log",
+			want:    rel("logs/.hidden.log"),
+		},
+		{
+			name:    "broken symlink skipped",
+			pattern: "logs/broken*",
+			want:    []string{},
+		},
+		{
+			name:    "broken symlink without following",
+			pattern: "logs/broken*",
+			opts:    []ResolveOption{NoFollowSymlinks()},
+			want:    rel("logs/broken.log"),
+		},
+		{
+			name:    "no follow skips symlinked directories",
+			pattern: "logs/**/b.log",
+			opts:    []ResolveOption{NoFollowSymlinks()},
+			want:    rel("logs/sub/b.log"),
+		},
+		{
+			name:    "existing path",
+			pattern: "logs/sub/d.txt",
+			want:    rel("logs/sub/d.txt"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePathRecursive(tt.pattern, tt.opts...)
+			if err != nil {
+				t.Fatalf("ResolvePathRecursive(%q) failed: %v", tt.pattern, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolvePathRecursive(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolvePathRecursive("logs/missing.log"); !os.IsNotExist(err) {
+		t.Errorf("ResolvePathRecursive of a missing path returned %v, want os.ErrNotExist", err)
+	}
+	if _, err := ResolvePathRecursive("logs/[.log"); err == nil {
+		t.Error("ResolvePathRecursive of an invalid pattern returned no error")
+	}
+}
+
+func TestResolvePathRecursivePattern(t *testing.T) {
+	dir := t.TempDir()
+	makeTree(t, dir, []string{"logs/a.log", "logs/sub/b.log"}, map[string]string{"logs/sub/loop": ".."})
+	chdir(t, dir)
+
+	got, err := ResolvePath("**/*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.FromSlash("logs/a.log"), filepath.FromSlash("logs/sub/b.log")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolvePath = %q, want %q", got, want)
+	}
+}
+
+func TestLoadJSONStream(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	errStop := errors.New("stop")
+
+	var large strings.Builder
+	var largeIDs []int
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&large, "{\"id\": %d}\n", i)
+		largeIDs = append(largeIDs, i)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		stop    int
+		want    []int
+		wantErr bool
+	}{
+		{name: "three objects", content: `{"id":1}` + "\n" + `{"id":2} {"id":3}`, want: []int{1, 2, 3}},
+		{name: "malformed middle object", content: `{"id":1}` + "\n" + `{"id":` + "\n" + `{"id":3}`, want: []int{1}, wantErr: true},
+		{name: "abort early", content: `{"id":1} {"id":2} {"id":3}`, stop: 2, want: []int{1, 2}, wantErr: true},
+		{name: "empty", content: "", want: nil},
+		{name: "whitespace only", content: " \n\t\n", want: nil},
+		{name: "large", content: large.String(), want: largeIDs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "stream.json")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []int
+			err := LoadJSONStream(file, func(v item) error {
+				got = append(got, v.ID)
+				if len(got) == tt.stop {
+					return errStop
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadJSONStream() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.stop > 0 && !errors.Is(err, errStop) {
+				t.Errorf("LoadJSONStream() error = %v, want %v", err, errStop)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadJSONStream() decoded %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := LoadJSONStream(filepath.Join(t.TempDir(), "missing.json"), func(item) error { return nil }); !os.IsNotExist(err) {
+		t.Errorf("LoadJSONStream of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestSaveJSONStream(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	want := []item{{1, "a"}, {2, "b\nc"}, {3, ""}}
+
+	file := filepath.Join(t.TempDir(), "stream.json")
+	if err := SaveJSONStream(file, want, 0644); err != nil {
+		t.Fatal(err)
+	}
+	var got []item
+	if err := LoadJSONStream(file, func(v item) error {
+		got = append(got, v)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadJSONStream(SaveJSONStream(%v)) = %v", want, got)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	makeTree(t, dir, []string{"a/file.txt", "b/x"}, map[string]string{"link": "a"})
+	chdir(t, filepath.Join(dir, "b"))
+
+	want := filepath.Join(dir, "a", "file.txt")
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "relative", path: "../a/file.txt", want: want},
+		{name: "relative with dots", path: "./../b/../a/./file.txt", want: want},
+		{name: "through symlink", path: "../link/file.txt", want: want},
+		{name: "absolute", path: filepath.Join(dir, "a", "..", "a", "file.txt"), want: want},
+		{name: "not existing", path: "../a/./missing/../new.txt", want: filepath.Join(dir, "a", "new.txt")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePath(tt.path)
+			if err != nil {
+				t.Fatalf("NormalizePath(%q) failed: %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateFileToLines(t *testing.T) {
+	lines := func(from, to int, trailingNewline bool) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			fmt.Fprintf(&b, "line %d %s", i, strings.Repeat("x", 50))
+			if i < to || trailingNewline {
+				b.WriteByte('\n')
+			}
+		}
+		return b.String()
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		keep    int
+		want    string
+	}{
+		{name: "large file", content: lines(1, 5000, true), keep: 100, want: lines(4901, 5000, true)},
+		{name: "no trailing newline", content: lines(1, 5000, false), keep: 100, want: lines(4901, 5000, false)},
+		{name: "fewer lines", content: lines(1, 50, true), keep: 100, want: lines(1, 50, true)},
+		{name: "exact number of lines", content: lines(1, 100, true), keep: 100, want: lines(1, 100, true)},
+		{name: "empty lines", content: "a\n\n\nb\n\n", keep: 2, want: "b\n\n"},
+		{name: "keep nothing", content: lines(1, 10, true), keep: 0, want: ""},
+		{name: "empty file", content: "", keep: 10, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "log")
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := TruncateFileToLines(file, tt.keep, 0644); err != nil {
+				t.Fatalf("TruncateFileToLines(%d) failed: %v", tt.keep, err)
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(data); got != tt.want {
+				t.Errorf("TruncateFileToLines(%d) left %d bytes, want %d", tt.keep, len(got), len(tt.want))
+			}
+		})
+	}
+
+	if err := TruncateFileToLines(filepath.Join(t.TempDir(), "missing"), 10, 0644); !os.IsNotExist(err) {
+		t.Errorf("TruncateFileToLines of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestReadTextUTF8(t *testing.T) {
+	encode := func(order binary.AppendByteOrder, bom bool, s string) []byte {
+		var b []byte
+		if bom {
+			b = order.AppendUint16(b, 0xFEFF)
+		}
+		for _, u := range utf16.Encode([]rune(s)) {
+			b = order.AppendUint16(b, u)
+		}
+		return b
+	}
+	const text = "Grüße, 世界 🎉\n"
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "utf-8 with bom", data: append([]byte{0xEF, 0xBB, 0xBF}, text...), want: text},
+		{name: "utf-8 without bom", data: []byte(text), want: text},
+		{name: "utf-16le", data: encode(binary.LittleEndian, true, text), want: text},
+		{name: "utf-16be", data: encode(binary.BigEndian, true, text), want: text},
+		{name: "utf-16 bom only", data: []byte{0xFF, 0xFE}, want: ""},
+		{name: "empty", data: nil, want: ""},
+		{name: "utf-16 odd length", data: append(encode(binary.LittleEndian, true, "ab"), 'c'), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "text")
+			if err := os.WriteFile(file, tt.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadTextUTF8(file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadTextUTF8() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadTextUTF8() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveFileSync(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "new", "data.txt")
+	if err := SaveFile(file, []byte("synced"), 0644, WithSync()); err != nil {
+		t.Fatalf("SaveFile() with WithSync failed: %v", err)
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "synced" {
+		t.Errorf("SaveFile() wrote %q, %v, want %q", data, err, "synced")
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("SaveFile() left %d entries in the directory, want 1", len(entries))
+	}
+}
+
+func TestSaveFilePerm(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissions are not supported on Windows")
+	}
+	dir := t.TempDir()
+
+	mode := func(file string) os.FileMode {
+		t.Helper()
+		fi, err := os.Stat(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi.Mode().Perm()
+	}
+
+	file := filepath.Join(dir, "new.txt")
+	if err := SaveFile(file, []byte("a"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if got := mode(file); got != 0640 {
+		t.Errorf("SaveFile() created file with mode %v, want %v", got, os.FileMode(0640))
+	}
+
+	// The umask is not applied
+	shared := filepath.Join(dir, "shared.txt")
+	if err := SaveFile(shared, []byte("a"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if got := mode(shared); got != 0666 {
+		t.Errorf("SaveFile() created file with mode %v, want %v", got, os.FileMode(0666))
+	}
+
+	existing := filepath.Join(dir, "existing.txt")
+	if err := os.WriteFile(existing, []byte("a"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(existing, 0604); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFile(existing, []byte("b"), 0640, WithPreserveMode()); err != nil {
+		t.Fatal(err)
+	}
+	if got := mode(existing); got != 0604 {
+		t.Errorf("SaveFile() with WithPreserveMode changed mode to %v, want %v", got, os.FileMode(0604))
+	}
+	if err := SaveFile(existing, []byte("c"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if got := mode(existing); got != 0640 {
+		t.Errorf("SaveFile() left mode %v, want %v", got, os.FileMode(0640))
+	}
+
+	preserved := filepath.Join(dir, "preserved.txt")
+	if err := SaveFile(preserved, []byte("a"), 0640, WithPreserveMode()); err != nil {
+		t.Fatal(err)
+	}
+	if got := mode(preserved); got != 0640 {
+		t.Errorf("SaveFile() with WithPreserveMode created file with mode %v, want %v", got, os.FileMode(0640))
+	}
+}
+
+func TestSaveFileBackup(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+
+	for _, content := range []string{"v1", "v2", "v3", "v4"} {
+		if err := SaveFile(file, []byte(content), 0644, WithBackup(3)); err != nil {
+			t.Fatalf("SaveFile(%q) with WithBackup failed: %v", content, err)
+		}
+	}
+
+	want := map[string]string{
+		"config.yaml":       "v4",
+		"config.yaml.bak":   "v3",
+		"config.yaml.bak.1": "v2",
+		"config.yaml.bak.2": "v1",
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(want) {
+		t.Errorf("SaveFile() with WithBackup left %d files, want %d", len(entries), len(want))
+	}
+	for name, content := range want {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || string(data) != content {
+			t.Errorf("%s = %q, %v, want %q", name, data, err, content)
+		}
+	}
+
+	// The oldest backup is dropped once the limit is reached
+	if err := SaveFile(file, []byte("v5"), 0644, WithBackup(3)); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(file + ".bak.2"); err != nil || string(data) != "v2" {
+		t.Errorf("config.yaml.bak.2 = %q, %v, want %q", data, err, "v2")
+	}
+	if _, err := os.Stat(file + ".bak.3"); !os.IsNotExist(err) {
+		t.Errorf("SaveFile() kept more than 3 backups")
+	}
+
+	fresh := filepath.Join(dir, "fresh.txt")
+	if err := SaveFile(fresh, []byte("v1"), 0644, WithBackup(1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fresh + ".bak"); !os.IsNotExist(err) {
+		t.Errorf("SaveFile() created a backup of a missing file")
+	}
+}
+
+func TestResolveWith(t *testing.T) {
+	dir := t.TempDir()
+	makeTree(t, dir,
+		[]string{"logs/a.log", "logs/b.LOG", "logs/c.txt", "logs/sub/d.log", "logs/sub/e.gz"},
+		map[string]string{"logs/link.log": "a.log"},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "logs/sub/d.log"), []byte("0123456789"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "logs/c.txt"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	rel := func(paths ...string) []string {
+		for i, p := range paths {
+			paths[i] = filepath.FromSlash(p)
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		opts    []ResolveOption
+		want    []string
+	}{
+		{
+			name:    "files",
+			pattern: "logs/**/*",
+			opts:    []ResolveOption{OnlyFiles()},
+			want:    rel("logs/a.log", "logs/b.LOG", "logs/c.txt", "logs/link.log", "logs/sub/d.log", "logs/sub/e.gz"),
+		},
+		{name: "dirs", pattern: "logs/**/*", opts: []ResolveOption{OnlyDirs()}, want: rel("logs/sub")},
+		{name: "symlinks", pattern: "logs/*", opts: []ResolveOption{OnlySymlinks()}, want: rel("logs/link.log")},
+		{
+			name:    "dirs or symlinks",
+			pattern: "logs/*",
+			opts:    []ResolveOption{OnlyDirs(), OnlySymlinks()},
+			want:    rel("logs/link.log", "logs/sub"),
+		},
+		{
+			name:    "extensions",
+			pattern: "logs/**/*",
+			opts:    []ResolveOption{WithExtensions("log", ".GZ")},
+			want:    rel("logs/a.log", "logs/b.LOG", "logs/link.log", "logs/sub/d.log", "logs/sub/e.gz"),
+		},
+		{
+			name:    "size",
+			pattern: "logs/**/*.log",
+			opts:    []ResolveOption{WithSize(1, 0)},
+			want:    rel("logs/sub/d.log"),
+		},
+		{
+			name:    "size range",
+			pattern: "logs/**/*.log",
+			opts:    []ResolveOption{OnlyFiles(), WithSize(0, 5)},
+			want:    rel("logs/a.log", "logs/link.log"),
+		},
+		{
+			name:    "mod time",
+			pattern: "logs/*",
+			opts:    []ResolveOption{WithModTime(time.Time{}, time.Now().Add(-time.Hour))},
+			want:    rel("logs/c.txt"),
+		},
+		{
+			name:    "filter",
+			pattern: "logs/**/*",
+			opts: []ResolveOption{WithFilter(func(path string, info os.FileInfo) bool {
+				return strings.HasPrefix(info.Name(), "d")
+			})},
+			want: rel("logs/sub/d.log"),
+		},
+		{
+			name:    "no follow",
+			pattern: "logs/*.log",
+			opts:    []ResolveOption{NoFollowSymlinks(), OnlyFiles()},
+			want:    rel("logs/a.log"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveWith(tt.pattern, tt.opts...)
+			if err != nil {
+				t.Fatalf("ResolveWith(%q) failed: %v", tt.pattern, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveWith(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDirs(t *testing.T) {
+	dir := t.TempDir()
+	makeTree(t, dir, []string{"a/file.txt", "b/file.txt", "c.txt"}, nil)
+	chdir(t, dir)
+
+	got, err := ResolveDirs("*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveDirs(%q) = %q, want %q", "*", got, want)
+	}
+	if _, err := ResolveDirs("missing"); !os.IsNotExist(err) {
+		t.Errorf("ResolveDirs of a missing path returned %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestSaveLoadYAML(t *testing.T) {
+	type config struct {
+		Name  string            `yaml:"name"`
+		Ports []int             `yaml:"ports"`
+		Tags  map[string]string `yaml:"tags"`
+	}
+	want := config{Name: "server", Ports: []int{80, 443}, Tags: map[string]string{"env": "prod"}}
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := SaveYAML(file, want, 0644); err != nil {
+		t.Fatalf("SaveYAML() failed: %v", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "ports:\n  - 80\n") {
+		t.Errorf("SaveYAML() did not indent by 2 spaces:\n%s", data)
+	}
+
+	var got config
+	if err := LoadYAML(file, &got); err != nil {
+		t.Fatalf("LoadYAML() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadYAML(SaveYAML(%v)) = %v", want, got)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.yaml")
+	if err := os.WriteFile(empty, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got = want
+	if err := LoadYAML(empty, &got); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadYAML() of an empty file = %v, %v, want unchanged value", got, err)
+	}
+}
+
+func TestSaveLoadTOML(t *testing.T) {
+	type server struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	}
+	type config struct {
+		Name    string   `toml:"name"`
+		Servers []server `toml:"servers"`
+	}
+	want := config{Name: "app", Servers: []server{{"a", 80}, {"b", 443}}}
+
+	file := filepath.Join(t.TempDir(), "config.toml")
+	if err := SaveTOML(file, want, 0644); err != nil {
+		t.Fatalf("SaveTOML() failed: %v", err)
+	}
+	var got config
+	if err := LoadTOML(file, &got); err != nil {
+		t.Fatalf("LoadTOML() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadTOML(SaveTOML(%v)) = %v", want, got)
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.toml")
+	if err := os.WriteFile(invalid, []byte("name = "), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadTOML(invalid, &got); err == nil {
+		t.Error("LoadTOML() of invalid TOML returned no error")
+	}
+}
+
+func TestAppendJSONLines(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	file := filepath.Join(t.TempDir(), "items.jsonl")
+
+	if err := AppendJSONLine(file, item{ID: 1}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendJSONLines(file, []item{{ID: 2}, {ID: 3}}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendJSONLines(file, []interface{}{item{ID: 4}, func() {}}, 0644); err == nil {
+		t.Error("AppendJSONLines() of an unsupported value returned no error")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"; got != want {
+		t.Errorf("AppendJSONLines() wrote %q, want %q", got, want)
+	}
+	if runtime.GOOS != "windows" {
+		if fi, err := os.Stat(file); err != nil {
+			t.Fatal(err)
+		} else if got := fi.Mode().Perm(); got != 0600 {
+			t.Errorf("AppendJSONLine() created file with mode %v, want %v", got, os.FileMode(0600))
+		}
+	}
+
+	// Concurrent appenders do not interleave their lines
+	concurrent := filepath.Join(t.TempDir(), "concurrent.jsonl")
+	line := strings.Repeat("x", 16<<10)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := AppendJSONLines(concurrent, []string{line, line}, 0644); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	seq, errf := ReadJSONLines[string](concurrent)
+	n := 0
+	seq(func(s string) bool {
+		if s != line {
+			t.Errorf("ReadJSONLines() returned a line of length %d, want %d", len(s), len(line))
+			return false
+		}
+		n++
+		return true
+	})
+	if err := errf(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 16 {
+		t.Errorf("ReadJSONLines() returned %d lines, want 16", n)
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	file := filepath.Join(t.TempDir(), "items.jsonl")
+
+	tests := []struct {
+		name    string
+		content string
+		stop    int
+		want    []int
+		wantErr bool
+	}{
+		{name: "lines", content: "{\"id\":1}\n{\"id\":2}\n", want: []int{1, 2}},
+		{name: "blank lines and no trailing newline", content: "\n{\"id\":1}\n  \n{\"id\":2}", want: []int{1, 2}},
+		{name: "stop early", content: "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n", stop: 2, want: []int{1, 2}},
+		{name: "invalid line", content: "{\"id\":1}\n{\"id\":\n{\"id\":3}\n", want: []int{1}, wantErr: true},
+		{name: "empty", content: "", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(file, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			seq, errf := ReadJSONLines[item](file)
+			var got []int
+			seq(func(v item) bool {
+				got = append(got, v.ID)
+				return len(got) != tt.stop
+			})
+			if err := errf(); (err != nil) != tt.wantErr {
+				t.Fatalf("ReadJSONLines() error = %v, want error %v", err, tt.wantErr)
+			} else if err != nil && !strings.Contains(err.Error(), ":2:") {
+				t.Errorf("ReadJSONLines() error = %v, want line number 2", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadJSONLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	seq, errf := ReadJSONLines[item](filepath.Join(t.TempDir(), "missing.jsonl"))
+	seq(func(item) bool { return true })
+	if err := errf(); !os.IsNotExist(err) {
+		t.Errorf("ReadJSONLines of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,27 @@
+//go:build unix
+
+package tools
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// copyOwner changes the owner and group of the named file to those of fi. Missing permissions are ignored, as
+// only privileged users may give away files.
+func copyOwner(name string, fi os.FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if err := os.Lchown(name, int(st.Uid), int(st.Gid)); err != nil && !errors.Is(err, os.ErrPermission) {
+		return err
+	}
+	return nil
+}
+
+// isCrossDevice reports whether err indicates a rename across file systems.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
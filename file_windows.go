@@ -0,0 +1,20 @@
+//go:build windows
+
+package tools
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// copyOwner is a no-op on Windows.
+func copyOwner(name string, fi os.FileInfo) error {
+	return nil
+}
+
+// isCrossDevice reports whether err indicates a rename across volumes.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, windows.ERROR_NOT_SAME_DEVICE)
+}
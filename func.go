@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// FixedPoint repeatedly applies f, starting with the initial value, until the result no longer changes. It returns
+// the last value and whether a fixed point was reached within maxIter applications of f.
+func FixedPoint[T comparable](initial T, f func(T) T, maxIter int) (T, bool) {
+	v := initial
+	for i := 0; i < maxIter; i++ {
+		next := f(v)
+		if next == v {
+			return v, true
+		}
+		v = next
+	}
+	return v, false
+}
+
+// PaceSlice calls fn for each value, spreading the calls evenly over the given duration. The first call happens
+// immediately. Processing stops at the first error returned by fn or when the context is canceled, in which case the
+// context's error is returned.
+func PaceSlice[T any](ctx context.Context, values []T, over time.Duration, fn func(T) error) error {
+	if len(values) == 0 {
+		return nil
+	}
+	interval := over / time.Duration(len(values))
+
+	start := time.Now()
+	for i, v := range values {
+		if i > 0 {
+			if err := sleepUntil(ctx, start.Add(time.Duration(i)*interval)); err != nil {
+				return err
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sleepUntil waits until the given time is reached or the context is canceled.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ParallelMap calls f for each value using up to the given number of concurrent workers and returns the results in
+// the order of the input values. All values are processed even if some calls fail. The errors are joined in input
+// order and returned along with the results, where failed elements hold the value returned with the error. A worker
+// count below 1 is treated as 1.
+func ParallelMap[T, R any](values []T, workers int, f func(T) (R, error)) ([]R, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(values) {
+		workers = len(values)
+	}
+
+	results := make([]R, len(values))
+	errs := make([]error, len(values))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = f(values[i])
+			}
+		}()
+	}
+	for i := range values {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFixedPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial int
+		f       func(int) int
+		maxIter int
+		want    int
+		wantOK  bool
+	}{
+		{name: "converges", initial: 100, f: func(x int) int { return x / 2 }, maxIter: 10, want: 0, wantOK: true},
+		{name: "already fixed", initial: 7, f: func(x int) int { return x }, maxIter: 1, want: 7, wantOK: true},
+		{name: "iteration cap", initial: 0, f: func(x int) int { return x + 1 }, maxIter: 5, want: 5, wantOK: false},
+		{name: "zero iterations", initial: 3, f: func(x int) int { return x }, maxIter: 0, want: 3, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FixedPoint(tt.initial, tt.f, tt.maxIter)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FixedPoint(%d) = %d, %v, want %d, %v", tt.initial, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPaceSlice(t *testing.T) {
+	t.Run("spacing", func(t *testing.T) {
+		var times []time.Duration
+		start := time.Now()
+		err := PaceSlice(context.Background(), []int{1, 2, 3, 4}, 200*time.Millisecond, func(int) error {
+			times = append(times, time.Since(start))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(times) != 4 {
+			t.Fatalf("PaceSlice called fn %d times, want 4", len(times))
+		}
+		if times[0] > 20*time.Millisecond {
+			t.Errorf("first call after %v, want it immediately", times[0])
+		}
+		for i := 1; i < len(times); i++ {
+			if want := time.Duration(i) * 50 * time.Millisecond; times[i] < want {
+				t.Errorf("call %d after %v, want at least %v", i, times[i], want)
+			}
+		}
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var got []int
+		err := PaceSlice(ctx, []int{1, 2, 3, 4}, time.Hour, func(v int) error {
+			got = append(got, v)
+			cancel()
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("PaceSlice() error = %v, want %v", err, context.Canceled)
+		}
+		if want := []int{1}; !reflect.DeepEqual(got, want) {
+			t.Errorf("PaceSlice() processed %v, want %v", got, want)
+		}
+	})
+
+	t.Run("canceled before start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := PaceSlice(ctx, []int{1}, time.Second, func(int) error {
+			calls++
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) || calls != 0 {
+			t.Errorf("PaceSlice() = %v after %d calls, want %v after none", err, calls, context.Canceled)
+		}
+	})
+
+	t.Run("error stops processing", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := PaceSlice(context.Background(), []int{1, 2, 3}, 0, func(int) error {
+			calls++
+			return errStop
+		})
+		if !errors.Is(err, errStop) || calls != 1 {
+			t.Errorf("PaceSlice() = %v after %d calls, want %v after 1", err, calls, errStop)
+		}
+	})
+}
+
+func TestParallelMap(t *testing.T) {
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+
+	var running, peak int32
+	got, err := ParallelMap(values, 4, func(v int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return v * v, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("ParallelMap()[%d] = %d, want %d", i, v, i*i)
+		}
+	}
+	if peak > 4 {
+		t.Errorf("ParallelMap ran %d workers concurrently, want at most 4", peak)
+	}
+
+	errOdd := errors.New("odd")
+	got, err = ParallelMap([]int{1, 2, 3, 4}, 0, func(v int) (int, error) {
+		if v%2 == 1 {
+			return -v, fmt.Errorf("%d: %w", v, errOdd)
+		}
+		return v, nil
+	})
+	if want := []int{-1, 2, -3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParallelMap() = %v, want %v", got, want)
+	}
+	if !errors.Is(err, errOdd) || err.Error() != "1: odd\n3: odd" {
+		t.Errorf("ParallelMap() error = %q, want both errors in input order", err)
+	}
+
+	if got, err := ParallelMap(nil, 3, func(v int) (int, error) { return v, nil }); err != nil || len(got) != 0 {
+		t.Errorf("ParallelMap(nil) = %v, %v, want an empty result", got, err)
+	}
+}
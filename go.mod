@@ -1,5 +1,14 @@
 module github.com/nmeilick/go-tools
 
-go 1.20
+go 1.22
 
-require golang.org/x/exp v0.0.0-20230510235704-dd950f8aeaea
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/fsnotify/fsnotify v1.7.0
+	github.com/klauspost/compress v1.18.0
+	golang.org/x/exp v0.0.0-20230510235704-dd950f8aeaea
+	golang.org/x/sys v0.14.0
+	golang.org/x/term v0.14.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// ErrChecksumMismatch is returned by VerifyFile if the checksum of the file differs from the expected one.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// hashAlgorithms maps the supported algorithm names to their constructors.
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+	"xxhash": func() hash.Hash { return xxhash.New() },
+}
+
+// ContentID returns a stable identifier for the given data, consisting of the first length characters of the
+// hex-encoded SHA-256 hash. If length is not positive or exceeds the hash length, the full hash is returned.
+func ContentID(data []byte, length int) string {
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:])
+	if length > 0 && length < len(id) {
+		id = id[:length]
+	}
+	return id
+}
+
+// HashFile returns the hex-encoded checksum of the given file. Supported algorithms are md5, sha1, sha256, sha512
+// and xxhash (64-bit XXH64).
+func HashFile(path, algo string) (string, error) {
+	h, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer h.Close()
+	return HashReader(h, algo)
+}
+
+// HashReader returns the hex-encoded checksum of everything read from r, see HashFile.
+func HashReader(r io.Reader, algo string) (string, error) {
+	newHash, ok := hashAlgorithms[strings.ToLower(algo)]
+	if !ok {
+		return "", fmt.Errorf("unsupported hash algorithm: %q", algo)
+	}
+	h := newHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyFile checks the given file against the expected hex-encoded checksum, which may be prefixed with the
+// algorithm, e.g. "sha256:9f86d0...". Without a prefix, the algorithm is derived from the length of the checksum,
+// where 16 characters denote xxhash. ErrChecksumMismatch is returned if the checksums differ.
+func VerifyFile(path, expected string) error {
+	algo, sum, ok := strings.Cut(strings.TrimSpace(expected), ":")
+	if !ok {
+		sum = algo
+		switch len(sum) {
+		case 16:
+			algo = "xxhash"
+		case 32:
+			algo = "md5"
+		case 40:
+			algo = "sha1"
+		case 64:
+			algo = "sha256"
+		case 128:
+			algo = "sha512"
+		default:
+			return fmt.Errorf("invalid checksum: %q", expected)
+		}
+	}
+
+	actual, err := HashFile(path, algo)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, sum) {
+		return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, path, strings.ToLower(sum), actual)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContentID(t *testing.T) {
+	const full = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" // SHA-256 of "hello"
+
+	tests := []struct {
+		name   string
+		length int
+		want   string
+	}{
+		{name: "short", length: 8, want: full[:8]},
+		{name: "one", length: 1, want: full[:1]},
+		{name: "full length", length: 64, want: full},
+		{name: "too long", length: 100, want: full},
+		{name: "zero", length: 0, want: full},
+		{name: "negative", length: -1, want: full},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ContentID([]byte("hello"), tt.length)
+			if got != tt.want {
+				t.Errorf("ContentID(%d) = %q, want %q", tt.length, got, tt.want)
+			}
+			if again := ContentID([]byte("hello"), tt.length); again != got {
+				t.Errorf("ContentID(%d) is not stable: %q, then %q", tt.length, got, again)
+			}
+		})
+	}
+
+	if a, b := ContentID([]byte("hello"), 16), ContentID([]byte("hello!"), 16); a == b {
+		t.Errorf("ContentID returned %q for different inputs", a)
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		algo string
+		want string
+	}{
+		{algo: "md5", want: "5d41402abc4b2a76b9719d911017c592"},
+		{algo: "sha1", want: "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"},
+		{algo: "SHA256", want: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{
+			algo: "sha512",
+			want: "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca7" +
+				"2323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043",
+		},
+		{algo: "xxhash", want: "26c7827d889f6da3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algo, func(t *testing.T) {
+			got, err := HashFile(file, tt.algo)
+			if err != nil {
+				t.Fatalf("HashFile(%q) failed: %v", tt.algo, err)
+			}
+			if got != tt.want {
+				t.Errorf("HashFile(%q) = %s, want %s", tt.algo, got, tt.want)
+			}
+			if got, err := HashReader(strings.NewReader("hello"), tt.algo); err != nil || got != tt.want {
+				t.Errorf("HashReader(%q) = %s, %v, want %s", tt.algo, got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := HashFile(file, "crc32"); err == nil {
+		t.Error("HashFile() with unsupported algorithm returned no error")
+	}
+	if _, err := HashFile(file+".missing", "md5"); !os.IsNotExist(err) {
+		t.Errorf("HashFile() of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestVerifyFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expected string
+		mismatch bool
+		invalid  bool
+	}{
+		{expected: "5d41402abc4b2a76b9719d911017c592"},
+		{expected: "AAF4C61DDCC5E8A2DABEDE0F3B482CD9AEA9434D"},
+		{expected: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{expected: "26c7827d889f6da3"},
+		{expected: " sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824 "},
+		{expected: "xxhash:26c7827d889f6da3"},
+		{expected: "5d41402abc4b2a76b9719d911017c593", mismatch: true},
+		{expected: "md5:26c7827d889f6da3", mismatch: true},
+		{expected: "abc", invalid: true},
+		{expected: "crc32:3610a686", invalid: true},
+	}
+	for _, tt := range tests {
+		err := VerifyFile(file, tt.expected)
+		switch {
+		case tt.mismatch:
+			if !errors.Is(err, ErrChecksumMismatch) {
+				t.Errorf("VerifyFile(%q) = %v, want %v", tt.expected, err, ErrChecksumMismatch)
+			}
+		case tt.invalid:
+			if err == nil || errors.Is(err, ErrChecksumMismatch) {
+				t.Errorf("VerifyFile(%q) = %v, want an invalid checksum error", tt.expected, err)
+			}
+		case err != nil:
+			t.Errorf("VerifyFile(%q) failed: %v", tt.expected, err)
+		}
+	}
+}
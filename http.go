@@ -2,8 +2,11 @@ package tools
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 )
 
 var httpClient *http.Client = &http.Client{
@@ -17,3 +20,25 @@ var httpClient *http.Client = &http.Client{
 func HTTPClient() *http.Client {
 	return httpClient
 }
+
+// ParseURL parses the given URL and makes sure its scheme is one of the allowed schemes, which default to http and
+// https. URLs using http or https must include a host.
+func ParseURL(s string, allowedSchemes ...string) (*url.URL, error) {
+	if len(allowedSchemes) == 0 {
+		allowedSchemes = []string{"http", "https"}
+	}
+
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if !Includes(Map(allowedSchemes, strings.ToLower), scheme) {
+		return nil, fmt.Errorf("invalid URL scheme %q in %q", u.Scheme, s)
+	}
+	if (scheme == "http" || scheme == "https") && u.Host == "" {
+		return nil, fmt.Errorf("missing host in URL %q", s)
+	}
+	return u, nil
+}
@@ -0,0 +1,35 @@
+package tools
+
+import "testing"
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		schemes  []string
+		wantHost string
+		wantErr  bool
+	}{
+		{name: "https", input: "https://example.com/path?q=1", wantHost: "example.com"},
+		{name: "http with port", input: " http://example.com:8080 ", wantHost: "example.com:8080"},
+		{name: "scheme case", input: "HTTPS://example.com", wantHost: "example.com"},
+		{name: "file rejected", input: "file:///etc/passwd", wantErr: true},
+		{name: "no scheme", input: "example.com", wantErr: true},
+		{name: "missing host", input: "https:///path", wantErr: true},
+		{name: "invalid", input: "http://[::1", wantErr: true},
+		{name: "custom scheme", input: "ftp://example.com", schemes: []string{"FTP"}, wantHost: "example.com"},
+		{name: "custom scheme rejects https", input: "https://example.com", schemes: []string{"ftp"}, wantErr: true},
+		{name: "file allowed explicitly", input: "file:///tmp/x", schemes: []string{"file"}, wantHost: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ParseURL(tt.input, tt.schemes...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseURL(%q) error = %v, want error %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && u.Host != tt.wantHost {
+				t.Errorf("ParseURL(%q) host = %q, want %q", tt.input, u.Host, tt.wantHost)
+			}
+		})
+	}
+}
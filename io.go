@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// ErrNoInput is returned by ReadStdin if stdin is a terminal rather than piped input.
+var ErrNoInput = errors.New("no input: stdin is a terminal")
+
+// ReadStdin reads all of stdin, but at most maxBytes bytes. If the input is larger, an error is returned. If stdin is
+// a terminal, ErrNoInput is returned instead of waiting for user input.
+func ReadStdin(maxBytes int64) ([]byte, error) {
+	if isTerminal(os.Stdin) {
+		return nil, ErrNoInput
+	}
+	return readLimited(os.Stdin, maxBytes)
+}
+
+// readLimited reads all data from r and fails if it exceeds maxBytes bytes.
+func readLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	// Read one byte more than allowed to detect oversized input.
+	limit := maxBytes
+	if limit < math.MaxInt64 {
+		limit++
+	}
+	data, err := io.ReadAll(io.LimitReader(r, limit))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("input exceeds limit of %d bytes", maxBytes)
+	}
+	return data, nil
+}
+
+// StreamJSONArray writes the given items to w as a JSON array, encoding one item at a time. If w has a Flush method,
+// like bufio.Writer or http.Flusher, it is called after every flushEvery items and at the end, keeping the amount of
+// buffered data bounded. A flushEvery value below 1 only flushes at the end.
+func StreamJSONArray[T any](w io.Writer, items []T, flushEvery int) error {
+	flush := func() error { return nil }
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		flush = f.Flush
+	case interface{ Flush() }:
+		flush = func() error {
+			f.Flush()
+			return nil
+		}
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err = w.Write(data); err != nil {
+			return err
+		}
+		if flushEvery > 0 && (i+1)%flushEvery == 0 {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := io.WriteString(w, "]\n"); err != nil {
+		return err
+	}
+	return flush()
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadLimited(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxBytes int64
+		wantErr  bool
+	}{
+		{name: "empty", input: "", maxBytes: 10},
+		{name: "below limit", input: "hello", maxBytes: 10},
+		{name: "at limit", input: "hello", maxBytes: 5},
+		{name: "above limit", input: "hello!", maxBytes: 5, wantErr: true},
+		{name: "zero limit", input: "x", maxBytes: 0, wantErr: true},
+		{name: "maximum limit", input: "hello", maxBytes: math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readLimited(strings.NewReader(tt.input), tt.maxBytes)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("readLimited() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readLimited() failed: %v", err)
+			}
+			if string(got) != tt.input {
+				t.Errorf("readLimited() = %q, want %q", got, tt.input)
+			}
+		})
+	}
+}
+
+// withStdin replaces os.Stdin for the duration of the test.
+func withStdin(t *testing.T, f *os.File) {
+	t.Helper()
+	old := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() { os.Stdin = old })
+}
+
+func TestReadStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	go func() {
+		w.Write(bytes.Repeat([]byte("x"), 100))
+		w.Close()
+	}()
+	withStdin(t, r)
+
+	data, err := ReadStdin(1000)
+	if err != nil {
+		t.Fatalf("ReadStdin() failed: %v", err)
+	}
+	if len(data) != 100 {
+		t.Errorf("ReadStdin() returned %d bytes, want 100", len(data))
+	}
+}
+
+func TestReadStdinLimit(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	go func() {
+		w.Write(bytes.Repeat([]byte("x"), 100))
+		w.Close()
+	}()
+	withStdin(t, r)
+
+	if _, err := ReadStdin(99); err == nil {
+		t.Error("ReadStdin() of oversized input returned no error")
+	}
+}
+
+func TestReadStdinNullDevice(t *testing.T) {
+	null, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer null.Close()
+	withStdin(t, null)
+
+	data, err := ReadStdin(10)
+	if errors.Is(err, ErrNoInput) {
+		t.Fatal("ReadStdin() treats the null device as a terminal")
+	}
+	if err != nil || len(data) != 0 {
+		t.Errorf("ReadStdin() = %q, %v, want no data", data, err)
+	}
+}
+
+// flushCounter is a buffer counting calls to Flush.
+type flushCounter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushCounter) Flush() error {
+	f.flushes++
+	return nil
+}
+
+func TestStreamJSONArray(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	large := make([]item, 10000)
+	for i := range large {
+		large[i] = item{ID: i, Name: fmt.Sprintf("item \"%d\"", i)}
+	}
+
+	tests := []struct {
+		name        string
+		items       []item
+		flushEvery  int
+		wantFlushes int
+	}{
+		{name: "large", items: large, flushEvery: 1000, wantFlushes: 11},
+		{name: "uneven flushes", items: large[:25], flushEvery: 10, wantFlushes: 3},
+		{name: "flush at end only", items: large[:25], flushEvery: 0, wantFlushes: 1},
+		{name: "single", items: large[:1], flushEvery: 1, wantFlushes: 2},
+		{name: "empty", items: []item{}, flushEvery: 1, wantFlushes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w flushCounter
+			if err := StreamJSONArray(&w, tt.items, tt.flushEvery); err != nil {
+				t.Fatal(err)
+			}
+			if !json.Valid(w.Bytes()) {
+				t.Fatalf("StreamJSONArray wrote invalid JSON: %.100q", w.String())
+			}
+			var got []item
+			if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.items) {
+				t.Errorf("StreamJSONArray wrote %d items, want %d", len(got), len(tt.items))
+			}
+			if w.flushes != tt.wantFlushes {
+				t.Errorf("StreamJSONArray flushed %d times, want %d", w.flushes, tt.wantFlushes)
+			}
+		})
+	}
+
+	var b bytes.Buffer
+	if err := StreamJSONArray(&b, []interface{}{1, make(chan int)}, 0); err == nil {
+		t.Error("StreamJSONArray of an unsupported value returned no error")
+	}
+}
@@ -0,0 +1,26 @@
+package tools
+
+import "sync"
+
+// Lazy holds a value that is computed on first use.
+type Lazy[T any] struct {
+	once  sync.Once
+	fn    func() (T, error)
+	value T
+	err   error
+}
+
+// NewLazy returns a Lazy that computes its value using the given function.
+func NewLazy[T any](fn func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{fn: fn}
+}
+
+// Get returns the value, computing it on the first call. The result and error of the first call are cached and
+// returned by all subsequent calls.
+func (l *Lazy[T]) Get() (T, error) {
+	l.once.Do(func() {
+		l.value, l.err = l.fn()
+		l.fn = nil
+	})
+	return l.value, l.err
+}
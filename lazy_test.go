@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazyConcurrentGet(t *testing.T) {
+	var calls int32
+	l := NewLazy(func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := l.Get(); v != 42 || err != nil {
+				t.Errorf("Get() = %v, %v, want 42, nil", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestLazyCachesError(t *testing.T) {
+	errFailed := errors.New("failed")
+	calls := 0
+	l := NewLazy(func() (string, error) {
+		calls++
+		return "", errFailed
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Get(); err != errFailed {
+			t.Errorf("Get() error = %v, want %v", err, errFailed)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrLocked is returned by TryLockFile if the file is locked by someone else.
+var ErrLocked = errors.New("file is locked")
+
+// LockFile acquires an exclusive advisory lock on the given file, creating it if necessary, and blocks until the
+// lock is available. The returned function releases the lock. Locks are held per open file, so they also exclude
+// other goroutines of the same process.
+func LockFile(path string) (unlock func(), err error) {
+	return acquireLock(path, true)
+}
+
+// TryLockFile works like LockFile but returns ErrLocked instead of waiting if the lock is held by someone else.
+func TryLockFile(path string) (unlock func(), err error) {
+	return acquireLock(path, false)
+}
+
+func acquireLock(path string, block bool) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if err = lockFile(f, block); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// WithLock holds an exclusive lock while the file is saved, serializing concurrent writers. As the file itself is
+// replaced, the lock is taken on a separate file named like the file with the suffix ".lock", which is left in
+// place.
+func WithLock() SaveOption {
+	return func(o *saveOptions) {
+		o.lock = true
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package tools
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func lockFile(f *os.File, block bool) error {
+	return fmt.Errorf("file locking is not supported on %s", runtime.GOOS)
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
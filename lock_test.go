@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.lock")
+
+	unlock, err := LockFile(file)
+	if err != nil {
+		t.Skipf("file locking not available: %v", err)
+	}
+	if _, err := TryLockFile(file); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryLockFile() on a locked file returned %v, want %v", err, ErrLocked)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := LockFile(file)
+		if err != nil {
+			t.Error(err)
+			close(acquired)
+			return
+		}
+		close(acquired)
+		unlock()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("LockFile() did not wait for the lock to be released")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("LockFile() did not acquire the released lock")
+	}
+
+	unlock, err = TryLockFile(file)
+	if err != nil {
+		t.Fatalf("TryLockFile() on an unlocked file failed: %v", err)
+	}
+	unlock()
+}
+
+func TestSaveFileWithLock(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "counter.txt")
+	if err := SaveFile(file, []byte("0"), 0644, WithLock()); err != nil {
+		t.Skipf("file locking not available: %v", err)
+	}
+	if _, err := os.Stat(file + ".lock"); err != nil {
+		t.Errorf("SaveFile() with WithLock did not create the lock file: %v", err)
+	}
+
+	unlock, err := LockFile(file + ".lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	saved := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := SaveFile(file, []byte("1"), 0644, WithLock()); err != nil {
+			t.Error(err)
+		}
+		close(saved)
+	}()
+
+	select {
+	case <-saved:
+		t.Error("SaveFile() with WithLock did not wait for the lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	wg.Wait()
+
+	if data, err := os.ReadFile(file); err != nil || string(data) != "1" {
+		t.Errorf("SaveFile() wrote %q, %v, want %q", data, err, "1")
+	}
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRUCache is a goroutine-safe cache holding a limited number of entries. When the capacity is exceeded, the least
+// recently used entry is evicted.
+type LRUCache[K comparable, V any] struct {
+	mutex    sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRUCache returns an LRU cache holding up to capacity entries. A capacity below 1 is treated as 1.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  map[K]*list.Element{},
+	}
+}
+
+// Get returns the value stored for the given key and marks it as recently used.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*lruEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put stores the value for the given key, evicting the least recently used entry if the cache is full.
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// Remove deletes the entry for the given key.
+func (c *LRUCache[K, V]) Remove(key K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRUCache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
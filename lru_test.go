@@ -0,0 +1,86 @@
+package tools
+
+import "testing"
+
+func TestLRUCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     func(c *LRUCache[string, int])
+		present []string
+		absent  []string
+	}{
+		{
+			name: "evicts oldest inserted",
+			ops: func(c *LRUCache[string, int]) {
+				c.Put("a", 1)
+				c.Put("b", 2)
+				c.Put("c", 3)
+				c.Put("d", 4)
+			},
+			present: []string{"b", "c", "d"},
+			absent:  []string{"a"},
+		},
+		{
+			name: "get marks as recently used",
+			ops: func(c *LRUCache[string, int]) {
+				c.Put("a", 1)
+				c.Put("b", 2)
+				c.Put("c", 3)
+				c.Get("a")
+				c.Put("d", 4)
+			},
+			present: []string{"a", "c", "d"},
+			absent:  []string{"b"},
+		},
+		{
+			name: "put updates and marks as recently used",
+			ops: func(c *LRUCache[string, int]) {
+				c.Put("a", 1)
+				c.Put("b", 2)
+				c.Put("c", 3)
+				c.Put("a", 10)
+				c.Put("d", 4)
+			},
+			present: []string{"a", "c", "d"},
+			absent:  []string{"b"},
+		},
+		{
+			name: "remove frees capacity",
+			ops: func(c *LRUCache[string, int]) {
+				c.Put("a", 1)
+				c.Put("b", 2)
+				c.Put("c", 3)
+				c.Remove("b")
+				c.Put("d", 4)
+			},
+			present: []string{"a", "c", "d"},
+			absent:  []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRUCache[string, int](3)
+			tt.ops(c)
+			if n := c.Len(); n != len(tt.present) {
+				t.Errorf("Len() = %d, want %d", n, len(tt.present))
+			}
+			for _, k := range tt.present {
+				if _, ok := c.Get(k); !ok {
+					t.Errorf("Get(%q) found nothing, want an entry", k)
+				}
+			}
+			for _, k := range tt.absent {
+				if v, ok := c.Get(k); ok {
+					t.Errorf("Get(%q) = %d, want no entry", k, v)
+				}
+			}
+		})
+	}
+
+	c := NewLRUCache[string, int](0)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if v, ok := c.Get("b"); !ok || v != 2 || c.Len() != 1 {
+		t.Errorf("LRU cache with capacity 0 holds %d entries, Get(\"b\") = %d, %v", c.Len(), v, ok)
+	}
+}
@@ -1,5 +1,11 @@
 package tools
 
+import (
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
 // Keys returns the keys of a map.
 func Keys[M ~map[K]V, K comparable, V any](m M) []K {
 	r := make([]K, 0, len(m))
@@ -17,3 +23,129 @@ func Values[M ~map[K]V, K comparable, V any](m M) []V {
 	}
 	return r
 }
+
+// SortedKeys returns the keys of a map in ascending order.
+func SortedKeys[M ~map[K]V, K constraints.Ordered, V any](m M) []K {
+	return Sort(Keys(m))
+}
+
+// SortedValues returns the values of a map in ascending order.
+func SortedValues[M ~map[K]V, K comparable, V constraints.Ordered](m M) []V {
+	return Sort(Values(m))
+}
+
+// Invert returns a map with keys and values swapped. If several keys share a value, it is undefined which of them
+// ends up in the result.
+func Invert[M ~map[K]V, K comparable, V comparable](m M) map[V]K {
+	r := make(map[V]K, len(m))
+	for k, v := range m {
+		r[v] = k
+	}
+	return r
+}
+
+// FilterMap returns a map with the entries for which f returns true.
+func FilterMap[M ~map[K]V, K comparable, V any](m M, f func(K, V) bool) M {
+	r := M{}
+	for k, v := range m {
+		if f(k, v) {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// MapValues returns a map with the same keys and each value replaced by the result of f.
+func MapValues[M ~map[K]V, K comparable, V, R any](m M, f func(V) R) map[K]R {
+	r := make(map[K]R, len(m))
+	for k, v := range m {
+		r[k] = f(v)
+	}
+	return r
+}
+
+// MapGet returns the value stored for the given key or deflt if the key is not present.
+func MapGet[K comparable, V any](m map[K]V, key K, deflt V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return deflt
+}
+
+// MapGetFunc returns the value stored for the given key. If the key is not present, the result of deflt is returned.
+func MapGetFunc[K comparable, V any](m map[K]V, key K, deflt func() V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return deflt()
+}
+
+// MergeOption configures how DeepMerge resolves conflicts.
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	keep   bool
+	append bool
+}
+
+// MergeKeepExisting makes DeepMerge keep the existing value on conflicts instead of overwriting it.
+func MergeKeepExisting() MergeOption {
+	return func(o *mergeOptions) {
+		o.keep = true
+	}
+}
+
+// MergeAppendSlices makes DeepMerge append slices of the same type instead of treating them as conflicting values.
+func MergeAppendSlices() MergeOption {
+	return func(o *mergeOptions) {
+		o.append = true
+	}
+}
+
+// DeepMerge returns a new map containing the entries of dst merged with those of src. Nested maps present on both
+// sides are merged recursively. By default, other conflicting values are overwritten with those of src, see
+// MergeKeepExisting and MergeAppendSlices for alternatives. The input maps are not modified, but values not affected
+// by the merge are shared with them.
+func DeepMerge(dst, src map[string]interface{}, opts ...MergeOption) map[string]interface{} {
+	o := &mergeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return deepMerge(dst, src, o)
+}
+
+func deepMerge(dst, src map[string]interface{}, o *mergeOptions) map[string]interface{} {
+	result := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		result[k] = v
+	}
+
+	for k, sv := range src {
+		dv, ok := result[k]
+		if !ok {
+			result[k] = sv
+			continue
+		}
+
+		dm, dIsMap := dv.(map[string]interface{})
+		sm, sIsMap := sv.(map[string]interface{})
+		if dIsMap && sIsMap {
+			result[k] = deepMerge(dm, sm, o)
+			continue
+		}
+
+		if o.append {
+			d, s := reflect.ValueOf(dv), reflect.ValueOf(sv)
+			if d.Kind() == reflect.Slice && d.Type() == s.Type() {
+				merged := reflect.MakeSlice(d.Type(), 0, d.Len()+s.Len())
+				result[k] = reflect.AppendSlice(reflect.AppendSlice(merged, d), s).Interface()
+				continue
+			}
+		}
+
+		if !o.keep {
+			result[k] = sv
+		}
+	}
+	return result
+}
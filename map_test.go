@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMapGet(t *testing.T) {
+	m := map[string]int{"a": 1, "zero": 0}
+
+	tests := []struct {
+		name string
+		key  string
+		want int
+	}{
+		{name: "present", key: "a", want: 1},
+		{name: "present zero value", key: "zero", want: 0},
+		{name: "absent", key: "b", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapGet(m, tt.key, -1); got != tt.want {
+				t.Errorf("MapGet(%q) = %d, want %d", tt.key, got, tt.want)
+			}
+
+			calls := 0
+			got := MapGetFunc(m, tt.key, func() int {
+				calls++
+				return -1
+			})
+			if got != tt.want {
+				t.Errorf("MapGetFunc(%q) = %d, want %d", tt.key, got, tt.want)
+			}
+			if _, ok := m[tt.key]; ok && calls != 0 {
+				t.Errorf("MapGetFunc(%q) called the default function for a present key", tt.key)
+			}
+		})
+	}
+
+	if got := MapGet(map[string]int(nil), "a", 5); got != 5 {
+		t.Errorf("MapGet(nil) = %d, want 5", got)
+	}
+}
+
+func TestMapHelpers(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 3, "c": 1}
+
+	if got, want := SortedKeys(m), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %q, want %q", got, want)
+	}
+	if got, want := SortedValues(m), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedValues() = %v, want %v", got, want)
+	}
+	if got, want := Invert(m), map[int]string{2: "b", 3: "a", 1: "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Invert() = %v, want %v", got, want)
+	}
+
+	odd := FilterMap(m, func(_ string, v int) bool { return v%2 == 1 })
+	if want := map[string]int{"a": 3, "c": 1}; !reflect.DeepEqual(odd, want) {
+		t.Errorf("FilterMap() = %v, want %v", odd, want)
+	}
+
+	labels := MapValues(m, func(v int) string { return strings.Repeat("*", v) })
+	if want := map[string]string{"a": "***", "b": "**", "c": "*"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("MapValues() = %v, want %v", labels, want)
+	}
+
+	if want := map[string]int{"b": 2, "a": 3, "c": 1}; !reflect.DeepEqual(m, want) {
+		t.Errorf("map helpers modified the input: %v", m)
+	}
+	if got := SortedKeys(map[string]int(nil)); got == nil || len(got) != 0 {
+		t.Errorf("SortedKeys(nil) = %#v, want an empty slice", got)
+	}
+}
+
+func TestDeepMerge(t *testing.T) {
+	dst := map[string]interface{}{
+		"name": "app",
+		"db":   map[string]interface{}{"host": "localhost", "port": 5432},
+		"tags": []string{"a"},
+	}
+	src := map[string]interface{}{
+		"db":    map[string]interface{}{"port": 6543, "user": "admin"},
+		"tags":  []string{"b"},
+		"debug": true,
+	}
+
+	tests := []struct {
+		name string
+		opts []MergeOption
+		want map[string]interface{}
+	}{
+		{
+			name: "overwrite",
+			want: map[string]interface{}{
+				"name":  "app",
+				"db":    map[string]interface{}{"host": "localhost", "port": 6543, "user": "admin"},
+				"tags":  []string{"b"},
+				"debug": true,
+			},
+		},
+		{
+			name: "keep existing",
+			opts: []MergeOption{MergeKeepExisting()},
+			want: map[string]interface{}{
+				"name":  "app",
+				"db":    map[string]interface{}{"host": "localhost", "port": 5432, "user": "admin"},
+				"tags":  []string{"a"},
+				"debug": true,
+			},
+		},
+		{
+			name: "append slices",
+			opts: []MergeOption{MergeAppendSlices()},
+			want: map[string]interface{}{
+				"name":  "app",
+				"db":    map[string]interface{}{"host": "localhost", "port": 6543, "user": "admin"},
+				"tags":  []string{"a", "b"},
+				"debug": true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeepMerge(dst, src, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeepMerge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if port := dst["db"].(map[string]interface{})["port"]; port != 5432 {
+		t.Errorf("DeepMerge modified the destination map: port = %v", port)
+	}
+	if tags := dst["tags"].([]string); !reflect.DeepEqual(tags, []string{"a"}) {
+		t.Errorf("DeepMerge modified the destination slice: %q", tags)
+	}
+
+	conflict := DeepMerge(map[string]interface{}{"x": map[string]interface{}{"a": 1}}, map[string]interface{}{"x": 2})
+	if conflict["x"] != 2 {
+		t.Errorf("DeepMerge replacing a map with a scalar = %v, want 2", conflict["x"])
+	}
+}
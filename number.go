@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+var reCount = regexp.MustCompile(`^([+-]?\d+(?:\.\d+)?)([a-zA-Z]*)$`)
+
+// countUnits maps lower-cased count suffixes to their multipliers.
+var countUnits = map[string]int64{
+	"":   1,
+	"k":  1e3,
+	"m":  1e6,
+	"g":  1e9,
+	"t":  1e12,
+	"p":  1e15,
+	"e":  1e18,
+	"ki": 1 << 10,
+	"mi": 1 << 20,
+	"gi": 1 << 30,
+	"ti": 1 << 40,
+	"pi": 1 << 50,
+	"ei": 1 << 60,
+}
+
+// ParseCount parses a count with an optional decimal (k, M, G, T, P, E) or binary (Ki, Mi, Gi, Ti, Pi, Ei) suffix,
+// e.g. "10k" is 10000 and "1Ki" is 1024. Suffixes are case-insensitive. Fractions are allowed as long as the result is
+// a whole number, e.g. "1.5k".
+func ParseCount(s string) (int64, error) {
+	cleaned := strings.Join(strings.Fields(s), "")
+
+	m := reCount.FindStringSubmatch(cleaned)
+	if m == nil {
+		return 0, fmt.Errorf("invalid count: %q", s)
+	}
+
+	mult, ok := countUnits[strings.ToLower(m[2])]
+	if !ok {
+		return 0, fmt.Errorf("invalid unit %q in count", m[2])
+	}
+
+	n, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return 0, fmt.Errorf("invalid number in count: %q", m[1])
+	}
+	n.Mul(n, new(big.Rat).SetInt64(mult))
+	if !n.IsInt() {
+		return 0, fmt.Errorf("count is not a whole number: %q", s)
+	} else if !n.Num().IsInt64() {
+		return 0, fmt.Errorf("count out of range: %q", s)
+	}
+	return n.Num().Int64(), nil
+}
+
+// NextPow2 returns the smallest power of two greater than or equal to n. For n <= 0, 1 is returned.
+func NextPow2(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
+// PrevPow2 returns the largest power of two less than or equal to n. For n <= 0, 0 is returned.
+func PrevPow2(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return 1 << (bits.Len(uint(n)) - 1)
+}
+
+// IsPow2 checks whether n is a power of two.
+func IsPow2(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// MapRange linearly rescales v from the input range to the output range and clamps the result to the output range.
+// Inverted ranges, where the low bound is greater than the high bound, are supported. If the input range has zero
+// width, outLo is returned.
+//
+// Example usage:
+//   result := MapRange(5.0, 0, 10, 100, 200)  // Output: 150
+func MapRange[T constraints.Float](v, inLo, inHi, outLo, outHi T) T {
+	if inLo == inHi {
+		return outLo
+	}
+
+	r := outLo + (v-inLo)*(outHi-outLo)/(inHi-inLo)
+
+	lo, hi := outLo, outHi
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if r < lo {
+		return lo
+	} else if r > hi {
+		return hi
+	}
+	return r
+}
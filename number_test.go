@@ -0,0 +1,100 @@
+package tools
+
+import "testing"
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "42", want: 42},
+		{input: "10k", want: 10000},
+		{input: "10K", want: 10000},
+		{input: "1Ki", want: 1024},
+		{input: "1ki", want: 1024},
+		{input: "2M", want: 2000000},
+		{input: "1Gi", want: 1 << 30},
+		{input: "1.5k", want: 1500},
+		{input: " 3 k ", want: 3000},
+		{input: "-2k", want: -2000},
+		{input: "8Ei", wantErr: true},
+		{input: "1.5", wantErr: true},
+		{input: "1.0001k", wantErr: true},
+		{input: "10x", wantErr: true},
+		{input: "k", wantErr: true},
+		{input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseCount(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCount(%q) error = %v, want error %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCount(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPow2(t *testing.T) {
+	tests := []struct {
+		n        int
+		wantNext int
+		wantPrev int
+		wantIs   bool
+	}{
+		{n: -5, wantNext: 1, wantPrev: 0, wantIs: false},
+		{n: 0, wantNext: 1, wantPrev: 0, wantIs: false},
+		{n: 1, wantNext: 1, wantPrev: 1, wantIs: true},
+		{n: 2, wantNext: 2, wantPrev: 2, wantIs: true},
+		{n: 3, wantNext: 4, wantPrev: 2, wantIs: false},
+		{n: 63, wantNext: 64, wantPrev: 32, wantIs: false},
+		{n: 64, wantNext: 64, wantPrev: 64, wantIs: true},
+		{n: 65, wantNext: 128, wantPrev: 64, wantIs: false},
+		{n: 1000, wantNext: 1024, wantPrev: 512, wantIs: false},
+		{n: 1 << 40, wantNext: 1 << 40, wantPrev: 1 << 40, wantIs: true},
+	}
+	for _, tt := range tests {
+		if got := NextPow2(tt.n); got != tt.wantNext {
+			t.Errorf("NextPow2(%d) = %d, want %d", tt.n, got, tt.wantNext)
+		}
+		if got := PrevPow2(tt.n); got != tt.wantPrev {
+			t.Errorf("PrevPow2(%d) = %d, want %d", tt.n, got, tt.wantPrev)
+		}
+		if got := IsPow2(tt.n); got != tt.wantIs {
+			t.Errorf("IsPow2(%d) = %v, want %v", tt.n, got, tt.wantIs)
+		}
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	tests := []struct {
+		name                        string
+		v, inLo, inHi, outLo, outHi float64
+		want                        float64
+	}{
+		{name: "midpoint", v: 5, inLo: 0, inHi: 10, outLo: 100, outHi: 200, want: 150},
+		{name: "low bound", v: 0, inLo: 0, inHi: 10, outLo: 100, outHi: 200, want: 100},
+		{name: "high bound", v: 10, inLo: 0, inHi: 10, outLo: 100, outHi: 200, want: 200},
+		{name: "clamped below", v: -5, inLo: 0, inHi: 10, outLo: 100, outHi: 200, want: 100},
+		{name: "clamped above", v: 15, inLo: 0, inHi: 10, outLo: 100, outHi: 200, want: 200},
+		{name: "inverted output", v: 2.5, inLo: 0, inHi: 10, outLo: 1, outHi: 0, want: 0.75},
+		{name: "inverted output clamped", v: 20, inLo: 0, inHi: 10, outLo: 1, outHi: 0, want: 0},
+		{name: "inverted input", v: 2, inLo: 10, inHi: 0, outLo: 0, outHi: 100, want: 80},
+		{name: "zero-width input", v: 7, inLo: 3, inHi: 3, outLo: 100, outHi: 200, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapRange(tt.v, tt.inLo, tt.inHi, tt.outLo, tt.outHi); got != tt.want {
+				t.Errorf("MapRange(%v, %v, %v, %v, %v) = %v, want %v",
+					tt.v, tt.inLo, tt.inHi, tt.outLo, tt.outHi, got, tt.want)
+			}
+		})
+	}
+
+	if got := MapRange[float32](0.5, 0, 1, 0, 10); got != 5 {
+		t.Errorf("MapRange(float32) = %v, want 5", got)
+	}
+}
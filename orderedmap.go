@@ -0,0 +1,207 @@
+package tools
+
+import (
+	"bytes"
+	"container/list"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// OrderedMap is a map that remembers the order in which keys were first inserted. It is encoded to and decoded from
+// JSON objects preserving that order. It is not safe for concurrent use. The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	order   *list.List
+	entries map[K]*list.Element
+}
+
+type orderedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewOrderedMap returns an empty ordered map.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+	m.init()
+	return m
+}
+
+func (m *OrderedMap[K, V]) init() {
+	if m.entries == nil {
+		m.order = list.New()
+		m.entries = map[K]*list.Element{}
+	}
+}
+
+// Set stores the value for the given key. Existing keys keep their position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	m.init()
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*orderedEntry[K, V]).value = value
+		return
+	}
+	m.entries[key] = m.order.PushBack(&orderedEntry[K, V]{key: key, value: value})
+}
+
+// Get returns the value stored for the given key.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.entries[key]; ok {
+		return e.Value.(*orderedEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes the given key.
+func (m *OrderedMap[K, V]) Delete(key K) {
+	if e, ok := m.entries[key]; ok {
+		m.order.Remove(e)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	m.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+// Range calls f for each entry in insertion order until f returns false.
+func (m *OrderedMap[K, V]) Range(f func(key K, value V) bool) {
+	if m.order == nil {
+		return
+	}
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedEntry[K, V])
+		if !f(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// MarshalJSON encodes the map as a JSON object with the keys in insertion order.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	var err error
+	m.Range(func(k K, v V) bool {
+		var key string
+		if key, err = formatMapKey(k); err != nil {
+			return false
+		}
+		var data []byte
+		if data, err = json.Marshal(key); err != nil {
+			return false
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(data)
+		buf.WriteByte(':')
+		if data, err = json.Marshal(v); err != nil {
+			return false
+		}
+		buf.Write(data)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, preserving the order of its keys. Existing entries are kept and
+// null is ignored.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t == nil {
+		return nil
+	} else if t != json.Delim('{') {
+		return fmt.Errorf("cannot decode %v into an ordered map", t)
+	}
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var key K
+		if err = parseMapKey(t.(string), &key); err != nil {
+			return err
+		}
+		var value V
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+
+	_, err := dec.Token()
+	return err
+}
+
+// formatMapKey converts a map key to a string the same way encoding/json does.
+func formatMapKey(key interface{}) (string, error) {
+	// Like encoding/json, string keys are used as is, even if they implement encoding.TextMarshaler
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if tm, ok := key.(encoding.TextMarshaler); ok {
+		data, err := tm.MarshalText()
+		return string(data), err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %T", key)
+}
+
+// parseMapKey converts a string to a map key the same way encoding/json does.
+func parseMapKey(s string, key interface{}) error {
+	if tu, ok := key.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(s))
+	}
+
+	v := reflect.ValueOf(key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid map key %q: %w", s, err)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid map key %q: %w", s, err)
+		}
+		v.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported map key type %s", v.Type())
+	}
+	return nil
+}
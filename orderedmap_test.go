@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("c", 1)
+	m.Set("a", 2)
+	m.Set("b", 3)
+	m.Set("c", 4)
+	m.Delete("a")
+	m.Delete("missing")
+
+	if got, want := m.Keys(), []string{"c", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %q, want %q", got, want)
+	}
+	if v, ok := m.Get("c"); v != 4 || !ok {
+		t.Errorf("Get(%q) = %d, %v, want 4, true", "c", v, ok)
+	}
+	if v, ok := m.Get("a"); v != 0 || ok {
+		t.Errorf("Get(%q) = %d, %v, want 0, false", "a", v, ok)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+
+	m.Set("a", 5)
+	var keys []string
+	m.Range(func(k string, _ int) bool {
+		keys = append(keys, k)
+		return len(keys) < 2
+	})
+	if want := []string{"c", "b"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Range stopped after %q, want %q", keys, want)
+	}
+
+	var zero OrderedMap[int, int]
+	if _, ok := zero.Get(1); ok || zero.Len() != 0 || len(zero.Keys()) != 0 {
+		t.Error("the zero value of OrderedMap is not empty")
+	}
+	zero.Set(1, 1)
+	if v, _ := zero.Get(1); v != 1 {
+		t.Error("the zero value of OrderedMap is not usable")
+	}
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		keys []string
+	}{
+		{name: "order preserved", data: `{"z":1,"a":2,"m":3}`, keys: []string{"z", "a", "m"}},
+		{name: "nested values", data: `{"b":{"x":[1,2]},"a":null}`, keys: []string{"b", "a"}},
+		{name: "empty", data: `{}`, keys: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewOrderedMap[string, interface{}]()
+			if err := json.Unmarshal([]byte(tt.data), m); err != nil {
+				t.Fatal(err)
+			}
+			if got := m.Keys(); !reflect.DeepEqual(got, tt.keys) {
+				t.Errorf("keys after decoding %s = %q, want %q", tt.data, got, tt.keys)
+			}
+			data, err := json.Marshal(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.data {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.data)
+			}
+		})
+	}
+
+	ints := NewOrderedMap[int, string]()
+	ints.Set(10, "ten")
+	ints.Set(-2, "minus two")
+	data, err := json.Marshal(ints)
+	if err != nil || string(data) != `{"10":"ten","-2":"minus two"}` {
+		t.Errorf("json.Marshal(int keys) = %s, %v", data, err)
+	}
+	decoded := NewOrderedMap[int, string]()
+	if err := json.Unmarshal(data, decoded); err != nil || !reflect.DeepEqual(decoded.Keys(), []int{10, -2}) {
+		t.Errorf("json.Unmarshal(int keys) = %v, %v", decoded.Keys(), err)
+	}
+
+	var null OrderedMap[string, int]
+	if err := json.Unmarshal([]byte(`null`), &null); err != nil || null.Len() != 0 {
+		t.Errorf("json.Unmarshal(null) = %v, len %d", err, null.Len())
+	}
+
+	for _, invalid := range []string{`[1]`, `{"a":"x"}`, `{"a":1`} {
+		m := NewOrderedMap[string, int]()
+		if err := json.Unmarshal([]byte(invalid), m); err == nil {
+			t.Errorf("json.Unmarshal(%s) returned no error", invalid)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"x":1}`), NewOrderedMap[int, int]()); err == nil {
+		t.Error("json.Unmarshal with an invalid int key returned no error")
+	}
+}
+
+func TestOrderedMapJSONByValue(t *testing.T) {
+	var m OrderedMap[string, int]
+	m.Set("b", 1)
+	m.Set("a", 2)
+
+	data, err := json.Marshal(struct{ M OrderedMap[string, int] }{m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"M":{"b":1,"a":2}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
+
+// upperKey is a string map key with a text encoding that differs from the string itself.
+type upperKey string
+
+func (k upperKey) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(k))), nil
+}
+
+func TestOrderedMapJSONTextMarshalerKey(t *testing.T) {
+	// Keys of a string type are used as is, like encoding/json does for maps
+	m := NewOrderedMap[upperKey, int]()
+	m.Set("a", 1)
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"a":1}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
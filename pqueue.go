@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"container/heap"
+
+	"golang.org/x/exp/constraints"
+)
+
+// PriorityQueue returns elements in priority order, as defined by a less function: the element for which less
+// reports it is less than all others is returned first. It is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	h *pqHeap[T]
+}
+
+// pqHeap implements heap.Interface.
+type pqHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *pqHeap[T]) Len() int           { return len(h.items) }
+func (h *pqHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *pqHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *pqHeap[T]) Push(x interface{}) { h.items = append(h.items, x.(T)) }
+
+func (h *pqHeap[T]) Pop() interface{} {
+	var zero T
+	n := len(h.items) - 1
+	v := h.items[n]
+	h.items[n] = zero
+	h.items = h.items[:n]
+	return v
+}
+
+// NewPriorityQueue returns an empty priority queue ordered by the given less function.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{h: &pqHeap[T]{less: less}}
+}
+
+// NewPriorityQueueBy returns an empty priority queue returning the element with the smallest key first.
+func NewPriorityQueueBy[T any, K constraints.Ordered](key func(T) K) *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool { return key(a) < key(b) })
+}
+
+// Len returns the number of elements in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return q.h.Len()
+}
+
+// Push adds an element to the queue.
+func (q *PriorityQueue[T]) Push(v T) {
+	heap.Push(q.h, v)
+}
+
+// PushAll adds all given elements to the queue.
+func (q *PriorityQueue[T]) PushAll(values ...T) {
+	q.h.items = append(q.h.items, values...)
+	heap.Init(q.h)
+}
+
+// Peek returns the element with the highest priority without removing it.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if q.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.h.items[0], true
+}
+
+// Pop removes and returns the element with the highest priority. The second return value is false if the queue is
+// empty.
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	if q.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(q.h).(T), true
+}
+
+// PopN removes and returns up to n elements in priority order.
+func (q *PriorityQueue[T]) PopN(n int) []T {
+	result := []T{}
+	for i := 0; i < n && q.h.Len() > 0; i++ {
+		result = append(result, heap.Pop(q.h).(T))
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for _, v := range []int{5, 1, 8, 3} {
+		q.Push(v)
+	}
+	q.PushAll(7, 2)
+
+	if v, ok := q.Peek(); v != 1 || !ok {
+		t.Errorf("Peek() = %d, %v, want 1, true", v, ok)
+	}
+	if q.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", q.Len())
+	}
+	if got, want := q.PopN(3), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PopN(3) = %v, want %v", got, want)
+	}
+
+	var rest []int
+	for {
+		v, ok := q.Pop()
+		if !ok {
+			break
+		}
+		rest = append(rest, v)
+	}
+	if want := []int{5, 7, 8}; !reflect.DeepEqual(rest, want) {
+		t.Errorf("Pop() returned %v, want %v", rest, want)
+	}
+
+	if _, ok := q.Peek(); ok {
+		t.Error("Peek() on an empty queue succeeded")
+	}
+	if got := q.PopN(2); got == nil || len(got) != 0 {
+		t.Errorf("PopN() on an empty queue = %#v, want an empty slice", got)
+	}
+}
+
+func TestPriorityQueueBy(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	q := NewPriorityQueueBy(func(t task) int { return t.priority })
+	q.PushAll(task{"low", 3}, task{"high", 1}, task{"mid", 2})
+
+	var names []string
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		names = append(names, v.name)
+	}
+	if want := []string{"high", "mid", "low"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("tasks returned in order %q, want %q", names, want)
+	}
+}
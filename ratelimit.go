@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// RateLimiter is a goroutine-safe token bucket rate limiter. The bucket holds up to burst tokens and is refilled at
+// a constant rate. Each event consumes one token.
+type RateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a rate limiter allowing count events per interval, both of which must be positive, with
+// bursts of up to burst events. The bucket starts full. A burst below 1 is treated as 1. NewRateLimiter panics if
+// count or per is not positive.
+func NewRateLimiter(count int, per time.Duration, burst int) *RateLimiter {
+	if count <= 0 || per <= 0 {
+		panic("non-positive rate for NewRateLimiter")
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   float64(count) / per.Seconds(),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// ParseRateLimiter returns a rate limiter for a specification like "10/s" or "100/5m". The interval is parsed using
+// ParseDuration and the count is used as the burst size.
+func ParseRateLimiter(spec string) (*RateLimiter, error) {
+	parts := strings.SplitN(strings.TrimSpace(spec), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid rate: %q", spec)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid count in rate: %q", spec)
+	}
+
+	interval := strings.TrimSpace(parts[1])
+	if interval != "" && unicode.IsLetter(rune(interval[0])) {
+		interval = "1" + interval
+	}
+	per, err := ParseDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+	if per <= 0 {
+		return nil, fmt.Errorf("invalid interval in rate: %q", spec)
+	}
+	return NewRateLimiter(count, per, count), nil
+}
+
+// refill adds the tokens accumulated since the last call. The mutex must be held.
+func (l *RateLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// Allow reports whether an event may happen now and, if so, consumes a token.
+func (l *RateLimiter) Allow() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.refill(time.Now())
+	if l.tokens >= 1 {
+		l.tokens--
+		return true
+	}
+	return false
+}
+
+// Wait blocks until an event may happen and consumes a token. It returns the context's error if the context is
+// canceled first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mutex.Lock()
+		now := time.Now()
+		l.refill(now)
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mutex.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mutex.Unlock()
+
+		if err := sleepUntil(ctx, now.Add(wait)); err != nil {
+			return err
+		}
+	}
+}
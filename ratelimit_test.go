@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiterPanics(t *testing.T) {
+	tests := []struct {
+		count int
+		per   time.Duration
+	}{
+		{count: 0, per: time.Second},
+		{count: -1, per: time.Second},
+		{count: 1, per: 0},
+		{count: 1, per: -time.Second},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewRateLimiter(%d, %v, 1) did not panic", tt.count, tt.per)
+				}
+			}()
+			NewRateLimiter(tt.count, tt.per, 1)
+		}()
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	l := NewRateLimiter(1, time.Hour, 3)
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("Allow() #%d = false, want true", i+1)
+		}
+	}
+	if l.Allow() {
+		t.Error("Allow() with empty bucket = true, want false")
+	}
+}
+
+func TestRateLimiterBurstCap(t *testing.T) {
+	l := NewRateLimiter(10, time.Second, 2)
+	for l.Allow() {
+	}
+
+	// An hour's worth of tokens must not exceed the burst size
+	l.mutex.Lock()
+	l.last = l.last.Add(-time.Hour)
+	l.mutex.Unlock()
+
+	for i := 0; i < 2; i++ {
+		if !l.Allow() {
+			t.Fatalf("Allow() #%d after refill = false, want true", i+1)
+		}
+	}
+	if l.Allow() {
+		t.Error("Allow() beyond burst = true, want false")
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(50, time.Second, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	// The first event uses the initial token, the other two wait 20ms each
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Wait() returned after %v, want at least 30ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	l = NewRateLimiter(1, time.Hour, 1)
+	l.Allow()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestParseRateLimiter(t *testing.T) {
+	tests := []struct {
+		spec      string
+		wantRate  float64
+		wantBurst float64
+		wantErr   bool
+	}{
+		{spec: "10/s", wantRate: 10, wantBurst: 10},
+		{spec: "100/5m", wantRate: 100.0 / 300, wantBurst: 100},
+		{spec: " 5 / 2s ", wantRate: 2.5, wantBurst: 5},
+		{spec: "10", wantErr: true},
+		{spec: "0/s", wantErr: true},
+		{spec: "x/s", wantErr: true},
+		{spec: "10/abc", wantErr: true},
+		{spec: "10/0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			l, err := ParseRateLimiter(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRateLimiter(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if l.rate != tt.wantRate || l.burst != tt.wantBurst {
+				t.Errorf("ParseRateLimiter(%q) = rate %v, burst %v, want %v, %v", tt.spec, l.rate, l.burst, tt.wantRate, tt.wantBurst)
+			}
+		})
+	}
+}
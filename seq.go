@@ -0,0 +1,163 @@
+package tools
+
+// Seq is a lazily evaluated sequence of values. It calls yield for each value until yield returns false. Its shape
+// matches iter.Seq, so sequences can be used with range-over-func where available.
+type Seq[T any] func(yield func(T) bool)
+
+// SeqOf returns a sequence over the elements of the given slice.
+func SeqOf[T any](values []T) Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MapSeq returns a sequence that applies f to each value of the given sequence.
+func MapSeq[T, R any](s Seq[T], f func(T) R) Seq[R] {
+	return func(yield func(R) bool) {
+		s(func(v T) bool {
+			return yield(f(v))
+		})
+	}
+}
+
+// Map returns a sequence that applies the mapping functions sequentially to each value.
+func (s Seq[T]) Map(funcs ...MapFunc[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		s(func(v T) bool {
+			for _, f := range funcs {
+				v = f(v)
+			}
+			return yield(v)
+		})
+	}
+}
+
+// Filter returns a sequence of the values for which the select function returns true.
+func (s Seq[T]) Filter(f SelectFunc[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		s(func(v T) bool {
+			if !f(v) {
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
+
+// Take returns a sequence of the first n values.
+func (s Seq[T]) Take(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		s(func(v T) bool {
+			count++
+			return yield(v) && count < n
+		})
+	}
+}
+
+// Skip returns a sequence without the first n values.
+func (s Seq[T]) Skip(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		count := 0
+		s(func(v T) bool {
+			if count < n {
+				count++
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
+
+// ForEach calls f for each value of the sequence.
+func (s Seq[T]) ForEach(f func(T)) {
+	s(func(v T) bool {
+		f(v)
+		return true
+	})
+}
+
+// Collect returns all values of the sequence as a slice.
+func (s Seq[T]) Collect() []T {
+	result := []T{}
+	s.ForEach(func(v T) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// Combinations returns a sequence of all combinations of k elements of the given slice, in lexicographic order of
+// their positions. Each combination is a new slice.
+func Combinations[T any](values []T, k int) Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(values)
+		if k < 0 || k > n {
+			return
+		}
+
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+		for {
+			combo := make([]T, k)
+			for i, j := range idx {
+				combo[i] = values[j]
+			}
+			if !yield(combo) {
+				return
+			}
+
+			// Advance the rightmost index that has not reached its final position
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+// Permutations returns a sequence of all permutations of the given slice. Each permutation is a new slice.
+func Permutations[T any](values []T) Seq[[]T] {
+	return func(yield func([]T) bool) {
+		perm := make([]T, len(values))
+		copy(perm, values)
+		if !yield(append([]T{}, perm...)) {
+			return
+		}
+
+		// Heap's algorithm, iterative form
+		c := make([]int, len(perm))
+		for i := 1; i < len(perm); {
+			if c[i] < i {
+				if i%2 == 0 {
+					perm[0], perm[i] = perm[i], perm[0]
+				} else {
+					perm[c[i]], perm[i] = perm[i], perm[c[i]]
+				}
+				if !yield(append([]T{}, perm...)) {
+					return
+				}
+				c[i]++
+				i = 1
+			} else {
+				c[i] = 0
+				i++
+			}
+		}
+	}
+}
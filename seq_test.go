@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	double := func(v int) int { return v * 2 }
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name string
+		seq  Seq[int]
+		want []int
+	}{
+		{name: "all", seq: SeqOf([]int{1, 2, 3}), want: []int{1, 2, 3}},
+		{name: "map", seq: SeqOf([]int{1, 2, 3}).Map(double, double), want: []int{4, 8, 12}},
+		{name: "filter", seq: SeqOf([]int{1, 2, 3, 4}).Filter(isEven), want: []int{2, 4}},
+		{name: "take", seq: SeqOf([]int{1, 2, 3, 4}).Take(2), want: []int{1, 2}},
+		{name: "take zero", seq: SeqOf([]int{1, 2}).Take(0), want: []int{}},
+		{name: "take more than available", seq: SeqOf([]int{1, 2}).Take(5), want: []int{1, 2}},
+		{name: "skip", seq: SeqOf([]int{1, 2, 3, 4}).Skip(3), want: []int{4}},
+		{name: "chained", seq: SeqOf([]int{1, 2, 3, 4, 5, 6}).Filter(isEven).Skip(1).Map(double).Take(1), want: []int{8}},
+		{name: "empty", seq: SeqOf([]int(nil)), want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.seq.Collect(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	upper := MapSeq(SeqOf([]string{"a", "b"}), strings.ToUpper).Collect()
+	if want := []string{"A", "B"}; !reflect.DeepEqual(upper, want) {
+		t.Errorf("MapSeq() = %q, want %q", upper, want)
+	}
+}
+
+func TestSeqLaziness(t *testing.T) {
+	calls := 0
+	s := SeqOf([]int{1, 2, 3, 4, 5}).Map(func(v int) int {
+		calls++
+		return v
+	})
+	if calls != 0 {
+		t.Fatalf("Map evaluated %d values before iteration", calls)
+	}
+
+	if got := s.Take(2).Collect(); len(got) != 2 {
+		t.Fatalf("Take(2) returned %v", got)
+	}
+	if calls != 2 {
+		t.Errorf("Take(2) evaluated %d values, want 2", calls)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		k      int
+		want   [][]string
+	}{
+		{
+			name:   "pairs",
+			values: []string{"a", "b", "c", "d"},
+			k:      2,
+			want:   [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+		},
+		{name: "all", values: []string{"a", "b", "c"}, k: 3, want: [][]string{{"a", "b", "c"}}},
+		{name: "zero", values: []string{"a", "b"}, k: 0, want: [][]string{{}}},
+		{name: "too many", values: []string{"a"}, k: 2, want: [][]string{}},
+		{name: "negative", values: []string{"a"}, k: -1, want: [][]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Combinations(tt.values, tt.k).Collect(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Combinations(%q, %d) = %q, want %q", tt.values, tt.k, got, tt.want)
+			}
+		})
+	}
+
+	if got := Combinations([]int{1, 2, 3, 4, 5}, 2).Take(3).Collect(); len(got) != 3 {
+		t.Errorf("Combinations().Take(3) returned %d combinations, want 3", len(got))
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []int
+		wantCount int
+	}{
+		{name: "three", values: []int{1, 2, 3}, wantCount: 6},
+		{name: "four", values: []int{1, 2, 3, 4}, wantCount: 24},
+		{name: "one", values: []int{1}, wantCount: 1},
+		{name: "empty", values: nil, wantCount: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			perms := Permutations(tt.values).Collect()
+			if len(perms) != tt.wantCount {
+				t.Fatalf("Permutations(%v) returned %d permutations, want %d", tt.values, len(perms), tt.wantCount)
+			}
+			seen := map[string]bool{}
+			for _, p := range perms {
+				key := fmt.Sprint(p)
+				if seen[key] {
+					t.Errorf("Permutations(%v) returned %v twice", tt.values, p)
+				}
+				seen[key] = true
+				if !EqualUnordered(p, tt.values) {
+					t.Errorf("Permutations(%v) returned %v, which is not a permutation", tt.values, p)
+				}
+			}
+		})
+	}
+
+	// Permutations must be independent slices.
+	perms := Permutations([]int{1, 2}).Collect()
+	perms[0][0] = 99
+	if perms[1][0] == 99 {
+		t.Error("Permutations returned slices sharing memory")
+	}
+}
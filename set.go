@@ -0,0 +1,75 @@
+package tools
+
+// Set is a collection of unique values. It is not safe for concurrent use.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a set containing the given values.
+func NewSet[T comparable](values ...T) Set[T] {
+	s := make(Set[T], len(values))
+	s.Add(values...)
+	return s
+}
+
+// Add adds the given values to the set.
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+// Remove removes the given values from the set.
+func (s Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+// Contains checks whether the set contains the given value.
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Union returns a new set containing the values of both sets.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	result := make(Set[T], len(s)+len(other))
+	for v := range s {
+		result[v] = struct{}{}
+	}
+	for v := range other {
+		result[v] = struct{}{}
+	}
+	return result
+}
+
+// Intersect returns a new set containing the values present in both sets.
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	result := Set[T]{}
+	for v := range s {
+		if other.Contains(v) {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set containing the values of this set that are not present in the other one.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	result := Set[T]{}
+	for v := range s {
+		if !other.Contains(v) {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// ToSlice returns the values of the set in no particular order.
+func (s Set[T]) ToSlice() []T {
+	return Keys(s)
+}
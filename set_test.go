@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet(1, 2, 2, 3)
+	if s.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", s.Len())
+	}
+
+	s.Add(4, 1)
+	s.Remove(2, 5)
+	for v, want := range map[int]bool{1: true, 2: false, 3: true, 4: true, 5: false} {
+		if got := s.Contains(v); got != want {
+			t.Errorf("Contains(%d) = %v, want %v", v, got, want)
+		}
+	}
+	if got, want := Sort(s.ToSlice()), []int{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToSlice() = %v, want %v", got, want)
+	}
+
+	var zero Set[int]
+	if zero.Contains(1) || zero.Len() != 0 {
+		t.Error("the zero value of Set is not empty")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := NewSet(1, 2, 3), NewSet(2, 3, 4)
+
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{name: "union", got: a.Union(b), want: []int{1, 2, 3, 4}},
+		{name: "intersect", got: a.Intersect(b), want: []int{2, 3}},
+		{name: "difference", got: a.Difference(b), want: []int{1}},
+		{name: "difference reversed", got: b.Difference(a), want: []int{4}},
+		{name: "union with empty", got: a.Union(nil), want: []int{1, 2, 3}},
+		{name: "intersect with empty", got: a.Intersect(NewSet[int]()), want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sort(tt.got.ToSlice()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if got := Sort(a.ToSlice()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("set operations modified the receiver: %v", got)
+	}
+}
@@ -1,9 +1,12 @@
 package tools
 
 import (
+	"fmt"
+	"math/rand"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"unicode"
 
 	"golang.org/x/exp/constraints"
@@ -52,7 +55,9 @@ func Select[T any](values []T, funcs ...SelectFunc[T]) []T {
 	return selected
 }
 
-// IsZero checks whether the given value has the default value for its type.
+// IsZero checks whether the given value has the default value for its type. Pointers and interfaces are
+// dereferenced, so a non-nil pointer to a zero value is considered zero as well. Arrays and structs are zero if all
+// of their elements or fields are zero according to these rules.
 func IsZero[T any](v T) bool {
 	return isZero(v)
 }
@@ -63,31 +68,90 @@ func IsNotZero[T any](v T) bool {
 }
 
 func isZero(i interface{}) bool {
-	v := reflect.ValueOf(i)
+	// Avoid reflection for the most common types
+	switch v := i.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case int64:
+		return v == 0
+	case int32:
+		return v == 0
+	case uint:
+		return v == 0
+	case uint64:
+		return v == 0
+	case uint32:
+		return v == 0
+	case uint8:
+		return v == 0
+	}
+	return isZeroValue(reflect.ValueOf(i))
+}
+
+// isZeroValue works on reflect.Value instead of interface{} so that unexported struct fields can be inspected, too.
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+		return isZeroValue(v.Elem())
+	case reflect.Array, reflect.Struct:
+		if !hasIndirection(v.Type()) {
+			return v.IsZero()
+		}
+	}
+
 	switch v.Kind() {
-	case reflect.Func, reflect.Map, reflect.Slice:
-		return v.IsNil()
 	case reflect.Array:
-		z := true
 		for i := 0; i < v.Len(); i++ {
-			z = z && isZero(v.Index(i).Interface())
+			if !isZeroValue(v.Index(i)) {
+				return false
+			}
 		}
-		return z
+		return true
 	case reflect.Struct:
-		z := true
 		for i := 0; i < v.NumField(); i++ {
-			z = z && isZero(v.Field(i).Interface())
+			if !isZeroValue(v.Field(i)) {
+				return false
+			}
 		}
-		return z
-	case reflect.Ptr:
-		if v.IsNil() {
-			return true
+		return true
+	}
+	return v.IsZero()
+}
+
+// indirectionCache caches the results of hasIndirection by type.
+var indirectionCache sync.Map
+
+// hasIndirection reports whether the given array or struct type contains pointers or interfaces, which isZeroValue
+// needs to dereference. Values of other array and struct types can be checked using reflect.Value.IsZero directly.
+func hasIndirection(t reflect.Type) bool {
+	if r, ok := indirectionCache.Load(t); ok {
+		return r.(bool)
+	}
+
+	var r bool
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		r = true
+	case reflect.Array:
+		r = hasIndirection(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !r; i++ {
+			r = hasIndirection(t.Field(i).Type)
 		}
-		return isZero(v.Elem().Interface())
 	}
-	// Compare other types directly:
-	z := reflect.Zero(v.Type())
-	return v.Interface() == z.Interface()
+	indirectionCache.Store(t, r)
+	return r
 }
 
 // Unique returns a copy of the slice with all duplicates removed.
@@ -107,17 +171,68 @@ func Unique[T comparable](values []T) []T {
 	return result
 }
 
+// UniqueBy returns a copy of the slice keeping only the first element for each key returned by keyFn.
+func UniqueBy[T any, K comparable](values []T, key func(T) K) []T {
+	if values == nil {
+		return nil
+	}
+
+	result := []T{}
+	seen := map[K]bool{}
+	for _, v := range values {
+		if k := key(v); !seen[k] {
+			seen[k] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 // Tokens splits the given values at whitespace or comma and returns lower-cased unique values.
 func Tokens[T ~string](values ...T) []T {
-	split := func(r rune) bool {
-		return unicode.IsSpace(r) || r == ','
+	return TokensFunc(TokenOptions{FoldCase: true}, values...)
+}
+
+// TokenOptions controls how TokensFunc splits values into tokens.
+type TokenOptions struct {
+	// FoldCase lower-cases all tokens. Deduplication is case-insensitive if set and case-sensitive otherwise.
+	FoldCase bool
+
+	// Separators lists the runes values are split at.
+	Separators string
+
+	// SeparatorFunc reports whether a rune is a separator. It takes precedence over Separators. If neither is set,
+	// values are split at whitespace or comma.
+	SeparatorFunc func(rune) bool
+
+	// TrimQuotes removes a pair of matching single or double quotes surrounding a token.
+	TrimQuotes bool
+}
+
+// TokensFunc splits the given values into unique tokens as configured by the given options. Surrounding whitespace
+// is removed from each token and empty tokens are skipped. The order of first occurrence is preserved.
+func TokensFunc[T ~string](opts TokenOptions, values ...T) []T {
+	split := opts.SeparatorFunc
+	if split == nil {
+		if opts.Separators != "" {
+			split = func(r rune) bool { return strings.ContainsRune(opts.Separators, r) }
+		} else {
+			split = isTokenSeparator
+		}
 	}
 
 	tokens := []T{}
 	seen := map[string]bool{}
 	for _, v := range values {
 		for _, s := range strings.FieldsFunc(string(v), split) {
-			if s = strings.ToLower(s); !seen[s] {
+			s = strings.TrimSpace(s)
+			if opts.TrimQuotes {
+				s = trimQuotes(s)
+			}
+			if opts.FoldCase {
+				s = strings.ToLower(s)
+			}
+			if s != "" && !seen[s] {
 				seen[s] = true
 				tokens = append(tokens, T(s))
 			}
@@ -126,6 +241,36 @@ func Tokens[T ~string](values ...T) []T {
 	return tokens
 }
 
+// isTokenSeparator reports whether the rune separates tokens by default, which is whitespace or comma.
+func isTokenSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == ','
+}
+
+// ParseList splits the given string at whitespace or comma like Tokens does and parses each element using the given
+// function. Duplicates and case are preserved. The first parse error is returned along with the offending element.
+//
+// Example usage:
+//   result, err := ParseList("1, 2 3", strconv.Atoi)  // Output: [1, 2, 3], nil
+func ParseList[T any](s string, parse func(string) (T, error)) ([]T, error) {
+	result := []T{}
+	for _, elem := range strings.FieldsFunc(s, isTokenSeparator) {
+		v, err := parse(elem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list element %q: %w", elem, err)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// trimQuotes removes a pair of matching quotes surrounding the given string.
+func trimQuotes(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
 // FirstNonEmpty returns the first non-empty element of the given list.
 // To use a fallback value, put it as the last element.
 func FirstNonEmpty[T any](values ...T) T {
@@ -149,6 +294,91 @@ func Includes[T comparable](values []T, value T) bool {
 	return false
 }
 
+// Find returns the first element for which the select function returns true. The second return value is false if
+// no element matches.
+func Find[T any](values []T, f SelectFunc[T]) (T, bool) {
+	if i := FindIndex(values, f); i >= 0 {
+		return values[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// FindLast returns the last element for which the select function returns true. The second return value is false if
+// no element matches.
+func FindLast[T any](values []T, f SelectFunc[T]) (T, bool) {
+	for i := len(values) - 1; i >= 0; i-- {
+		if f(values[i]) {
+			return values[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// FindIndex returns the index of the first element for which the select function returns true or -1 if no element
+// matches.
+func FindIndex[T any](values []T, f SelectFunc[T]) int {
+	for i := range values {
+		if f(values[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Any returns true if the select function returns true for at least one element.
+func Any[T any](values []T, f SelectFunc[T]) bool {
+	return FindIndex(values, f) >= 0
+}
+
+// All returns true if the select function returns true for all elements, which includes the case of an empty slice.
+func All[T any](values []T, f SelectFunc[T]) bool {
+	for i := range values {
+		if !f(values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true if both slices have the same length and the same elements in the same order.
+func Equal[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualUnordered returns true if both slices contain the same elements the same number of times, regardless of their
+// order.
+//
+// Example usage:
+//   EqualUnordered([]int{1, 2, 2}, []int{2, 1, 2})  // Output: true
+//   EqualUnordered([]int{1, 2, 2}, []int{1, 1, 2})  // Output: false
+func EqualUnordered[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := map[T]int{}
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+	return true
+}
+
 // Minus is a generic function that returns a new slice including only those elements of the first input slice
 // that are not present in the second input slice.
 //
@@ -202,6 +432,95 @@ func Sort[T constraints.Ordered](values []T) []T {
 	return result
 }
 
+// Number is a constraint matching all integer and floating point types.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Sum returns the sum of all values.
+func Sum[T Number](values []T) T {
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// Avg returns the arithmetic mean of all values or 0 if the slice is empty.
+func Avg[T Number](values []T) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	return sum / float64(len(values))
+}
+
+// Min returns the smallest value. The second return value is false if the slice is empty.
+func Min[T constraints.Ordered](values []T) (T, bool) {
+	min, _, ok := MinMax(values)
+	return min, ok
+}
+
+// Max returns the largest value. The second return value is false if the slice is empty.
+func Max[T constraints.Ordered](values []T) (T, bool) {
+	_, max, ok := MinMax(values)
+	return max, ok
+}
+
+// MinMax returns the smallest and the largest value. The third return value is false if the slice is empty.
+//
+// Example usage:
+//   s := []int{3, 1, 4}
+//   min, max, ok := MinMax(s)  // Output: 1, 4, true
+func MinMax[T constraints.Ordered](values []T) (min, max T, ok bool) {
+	if len(values) == 0 {
+		return min, max, false
+	}
+
+	min, max = values[0], values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max, true
+}
+
+// SortBy returns a copy of a slice sorted by the key returned for each element. Elements with equal keys keep their
+// original order.
+//
+// Example usage:
+//   s := []string{"ccc", "a", "bb"}
+//   result := SortBy(s, func(s string) int { return len(s) })  // Output: ["a", "bb", "ccc"]
+func SortBy[T any, K constraints.Ordered](values []T, key func(T) K) []T {
+	pairs := make([]Pair[K, T], len(values))
+	for i, v := range values {
+		pairs[i] = Pair[K, T]{First: key(v), Second: v}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].First < pairs[j].First })
+
+	result := make([]T, len(values))
+	for i, p := range pairs {
+		result[i] = p.Second
+	}
+	return result
+}
+
+// SortStableFunc returns a copy of a slice sorted using the given less function. Equal elements keep their original
+// order.
+func SortStableFunc[T any](values []T, less func(a, b T) bool) []T {
+	result := make([]T, len(values))
+	copy(result, values)
+	sort.SliceStable(result, func(i, j int) bool { return less(result[i], result[j]) })
+	return result
+}
+
 // SortNatural returns a naturally sorted copy of a slice of string type.
 //
 // Example usage:
@@ -223,6 +542,61 @@ func SortNatural[T ~string](values []T, ignoreCase bool) []T {
 	return result
 }
 
+// SearchSorted searches the target in a slice sorted in ascending order, like the one returned by Sort. It returns
+// the position of the target or, if it is not present, the position where it would be inserted, and whether it was
+// found.
+//
+// Example usage:
+//   s := []int{10, 20, 30}
+//   i, found := SearchSorted(s, 25)  // Output: 2, false
+func SearchSorted[T constraints.Ordered](values []T, target T) (int, bool) {
+	i := sort.Search(len(values), func(i int) bool { return values[i] >= target })
+	return i, i < len(values) && values[i] == target
+}
+
+// SearchSortedFunc works like SearchSorted but uses the given comparison function, which returns a negative number if
+// the element sorts before the target, a positive number if it sorts after it and zero if it matches.
+func SearchSortedFunc[T, K any](values []T, target K, cmp func(T, K) int) (int, bool) {
+	i := sort.Search(len(values), func(i int) bool { return cmp(values[i], target) >= 0 })
+	return i, i < len(values) && cmp(values[i], target) == 0
+}
+
+// TopN returns the n largest values in descending order without sorting the whole slice.
+//
+// Example usage:
+//   s := []int{5, 1, 9, 3, 7}
+//   result := TopN(s, 2)  // Output: [9, 7]
+func TopN[T constraints.Ordered](values []T, n int) []T {
+	return TopNBy(values, n, func(v T) T { return v })
+}
+
+// TopNBy returns the n elements with the largest keys in descending order of their keys without sorting the whole
+// slice.
+func TopNBy[T any, K constraints.Ordered](values []T, n int, key func(T) K) []T {
+	if n <= 0 {
+		return []T{}
+	}
+
+	// Keep the n largest elements seen so far in a heap with the smallest of them on top.
+	q := NewPriorityQueue(func(a, b Pair[K, T]) bool { return a.First < b.First })
+	for _, v := range values {
+		k := key(v)
+		if q.Len() < n {
+			q.Push(Pair[K, T]{First: k, Second: v})
+		} else if min, _ := q.Peek(); k > min.First {
+			q.Pop()
+			q.Push(Pair[K, T]{First: k, Second: v})
+		}
+	}
+
+	result := make([]T, q.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		p, _ := q.Pop()
+		result[i] = p.Second
+	}
+	return result
+}
+
 func naturalLess(s1, s2 string) bool {
 	var chunk1, chunk2 string
 	var isNum1, isNum2 bool
@@ -297,3 +671,512 @@ func ToMap[K comparable, V any](keys []K, gen KeyValueGenerator[K, V]) map[K]V {
 func ToMapWithValue[K comparable, V any](keys []K, v V) map[K]V {
 	return ToMap(keys, func(k K) (K, V) { return k, v })
 }
+
+// Nearest returns the element with the smallest absolute difference to the target. If several elements are equally
+// close, the first one is returned. The second return value is false if the slice is empty.
+//
+// Example usage:
+//   s := []int{10, 20, 30}
+//   result, ok := Nearest(s, 24)  // Output: 20, true
+func Nearest[T Number](values []T, target T) (T, bool) {
+	if len(values) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	dist := func(v T) T {
+		if v > target {
+			return v - target
+		}
+		return target - v
+	}
+
+	nearest, best := values[0], dist(values[0])
+	for _, v := range values[1:] {
+		if d := dist(v); d < best {
+			nearest, best = v, d
+		}
+	}
+	return nearest, true
+}
+
+// Interleave returns a new slice taking one element from each input slice in turn until all of them are exhausted.
+//
+// Example usage:
+//   s1 := []int{1, 2, 3}
+//   s2 := []int{4}
+//   s3 := []int{5, 6}
+//   result := Interleave(s1, s2, s3)  // Output: [1, 4, 5, 2, 6, 3]
+func Interleave[T any](slices ...[]T) []T {
+	total, longest := 0, 0
+	for _, s := range slices {
+		total += len(s)
+		if len(s) > longest {
+			longest = len(s)
+		}
+	}
+
+	result := make([]T, 0, total)
+	for i := 0; i < longest; i++ {
+		for _, s := range slices {
+			if i < len(s) {
+				result = append(result, s[i])
+			}
+		}
+	}
+	return result
+}
+
+// MarshalList joins the given values using the separator. Backslashes and occurrences of the separator within values
+// are escaped using a backslash, so that UnmarshalList restores the original values. The separator must not contain
+// a backslash.
+//
+// Example usage:
+//   result := MarshalList([]string{"a,b", "c"}, ",")  // Output: `a\,b,c`
+func MarshalList(values []string, sep string) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		v = strings.ReplaceAll(v, `\`, `\\`)
+		if sep != "" {
+			v = strings.ReplaceAll(v, sep, `\`+sep)
+		}
+		escaped[i] = v
+	}
+	return strings.Join(escaped, sep)
+}
+
+// UnmarshalList splits a string created by MarshalList into its values. An empty string results in an empty slice.
+func UnmarshalList(s, sep string) []string {
+	values := []string{}
+	if s == "" {
+		return values
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			if sep != "" && strings.HasPrefix(s[i+1:], sep) {
+				b.WriteString(sep)
+				i += 1 + len(sep)
+			} else {
+				b.WriteByte(s[i+1])
+				i += 2
+			}
+		case sep != "" && strings.HasPrefix(s[i:], sep):
+			values = append(values, b.String())
+			b.Reset()
+			i += len(sep)
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return append(values, b.String())
+}
+
+// Duplicates returns the distinct elements that occur more than once, in the order their first duplicate was seen.
+//
+// Example usage:
+//   s := []string{"a", "b", "b", "a", "c", "b"}
+//   result := Duplicates(s)  // Output: ["b", "a"]
+func Duplicates[T comparable](values []T) []T {
+	result := []T{}
+	count := map[T]int{}
+	for _, v := range values {
+		count[v]++
+		if count[v] == 2 {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// SplitAt splits the slice at the first element for which the select function returns true. The matching element is
+// included in neither part. If no element matches, all values are returned as before and found is false.
+//
+// Example usage:
+//   s := []string{"Host: x", "", "body"}
+//   before, after, found := SplitAt(s, func(s string) bool { return s == "" })  // Output: ["Host: x"], ["body"], true
+func SplitAt[T any](values []T, pred SelectFunc[T]) (before, after []T, found bool) {
+	for i, v := range values {
+		if pred(v) {
+			return values[:i], values[i+1:], true
+		}
+	}
+	return values, nil, false
+}
+
+// MergeByKey merges the given slices keeping a single element per key. If several elements share a key, better is
+// called with the current and the new element and the new one replaces the current one if better returns true. The
+// order in which keys were first seen is preserved.
+//
+// Example usage:
+//   s1 := []Record{{ID: 1, Rev: 1}, {ID: 2, Rev: 1}}
+//   s2 := []Record{{ID: 1, Rev: 2}}
+//   key := func(r Record) int { return r.ID }
+//   better := func(a, b Record) bool { return b.Rev > a.Rev }
+//   result := MergeByKey(key, better, s1, s2)  // Output: [{1 2} {2 1}]
+func MergeByKey[T any, K comparable](keyFn func(T) K, better func(a, b T) bool, slices ...[]T) []T {
+	result := []T{}
+	index := map[K]int{}
+	for _, s := range slices {
+		for _, v := range s {
+			k := keyFn(v)
+			if i, ok := index[k]; !ok {
+				index[k] = len(result)
+				result = append(result, v)
+			} else if better(result[i], v) {
+				result[i] = v
+			}
+		}
+	}
+	return result
+}
+
+// GroupBy groups the values by the key returned by keyFn. The order of values within each group is preserved.
+//
+// Example usage:
+//   s := []string{"apple", "avocado", "banana"}
+//   result := GroupBy(s, func(s string) byte { return s[0] })  // Output: map[a:[apple avocado] b:[banana]]
+func GroupBy[T any, K comparable](values []T, keyFn func(T) K) map[K][]T {
+	groups := map[K][]T{}
+	for _, v := range values {
+		k := keyFn(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Reduce combines all values into a single result by calling f with the accumulator and each value in turn,
+// starting with init.
+//
+// Example usage:
+//   s := []int{1, 2, 3}
+//   result := Reduce(s, 0, func(sum, v int) int { return sum + v })  // Output: 6
+func Reduce[T, A any](values []T, init A, f func(A, T) A) A {
+	acc := init
+	for _, v := range values {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Chunk splits the slice into batches of at most size elements. The batches share memory with the input slice. A
+// size below 1 is treated as 1.
+//
+// Example usage:
+//   s := []int{1, 2, 3, 4, 5}
+//   result := Chunk(s, 2)  // Output: [[1, 2], [3, 4], [5]]
+func Chunk[T any](values []T, size int) [][]T {
+	if size < 1 {
+		size = 1
+	}
+
+	chunks := make([][]T, 0, (len(values)+size-1)/size)
+	for i := 0; i < len(values); i += size {
+		end := i + size
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, values[i:end:end])
+	}
+	return chunks
+}
+
+// Partition splits the values into those for which the select function returns true and the rest.
+//
+// Example usage:
+//   s := []int{1, 2, 3, 4}
+//   even, odd := Partition(s, func(v int) bool { return v%2 == 0 })  // Output: [2, 4], [1, 3]
+func Partition[T any](values []T, f SelectFunc[T]) (matched, rest []T) {
+	matched, rest = []T{}, []T{}
+	for _, v := range values {
+		if f(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip combines two slices into a slice of pairs. If the slices differ in length, the result is as long as the
+// shorter one.
+//
+// Example usage:
+//   names := []string{"a", "b"}
+//   ids := []int{1, 2}
+//   result := Zip(names, ids)  // Output: [{a 1} {b 2}]
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	pairs := make([]Pair[A, B], n)
+	for i := range pairs {
+		pairs[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return pairs
+}
+
+// Unzip splits a slice of pairs into two slices, reversing Zip.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a, b := make([]A, len(pairs)), make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.First, p.Second
+	}
+	return a, b
+}
+
+// Flatten concatenates all inner slices of the given nested slices. Unlike Merge, duplicates are kept and the
+// elements do not need to be comparable.
+//
+// Example usage:
+//   s := [][]int{{1, 2}, {2, 3}}
+//   result := Flatten(s)  // Output: [1, 2, 2, 3]
+func Flatten[T any](slices ...[][]T) []T {
+	n := 0
+	for _, nested := range slices {
+		for _, s := range nested {
+			n += len(s)
+		}
+	}
+
+	result := make([]T, 0, n)
+	for _, nested := range slices {
+		for _, s := range nested {
+			result = append(result, s...)
+		}
+	}
+	return result
+}
+
+// FlatMap calls f for each value and concatenates the returned slices.
+//
+// Example usage:
+//   s := []string{"a b", "c"}
+//   result := FlatMap(s, strings.Fields)  // Output: ["a", "b", "c"]
+func FlatMap[T, R any](values []T, f func(T) []R) []R {
+	result := []R{}
+	for _, v := range values {
+		result = append(result, f(v)...)
+	}
+	return result
+}
+
+// Intersect returns the elements of the first slice that are also present in the second one. Duplicates are removed
+// and the order of the first slice is preserved.
+//
+// Example usage:
+//   s1 := []int{1, 2, 3, 2}
+//   s2 := []int{2, 3, 4}
+//   result := Intersect(s1, s2)  // Output: [2, 3]
+func Intersect[T comparable](s1, s2 []T) []T {
+	return IntersectAll(s1, s2)
+}
+
+// IntersectAll returns the elements present in all of the given slices. Duplicates are removed and the order of the
+// first slice is preserved. Without any slices, an empty slice is returned.
+func IntersectAll[T comparable](slices ...[]T) []T {
+	result := []T{}
+	if len(slices) == 0 {
+		return result
+	}
+
+	others := make([]map[T]bool, len(slices)-1)
+	for i, s := range slices[1:] {
+		others[i] = map[T]bool{}
+		for _, v := range s {
+			others[i][v] = true
+		}
+	}
+
+	seen := map[T]bool{}
+	for _, v := range slices[0] {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+
+		inAll := true
+		for _, m := range others {
+			if !m[v] {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Reverse returns a copy of a slice with the elements in reverse order.
+func Reverse[T any](values []T) []T {
+	result := make([]T, len(values))
+	for i, v := range values {
+		result[len(values)-1-i] = v
+	}
+	return result
+}
+
+// Shuffle returns a randomly shuffled copy of a slice. If a random source is given, it is used instead of the global
+// one, which allows for reproducible results.
+func Shuffle[T any](values []T, rnd ...*rand.Rand) []T {
+	result := make([]T, len(values))
+	copy(result, values)
+	shuffle := rand.Shuffle
+	if len(rnd) > 0 && rnd[0] != nil {
+		shuffle = rnd[0].Shuffle
+	}
+	shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	return result
+}
+
+// Sample returns n randomly chosen elements of a slice. Each element is picked at most once, so the result is
+// shorter than n if the slice has fewer elements. If a random source is given, it is used instead of the global one.
+func Sample[T any](values []T, n int, rnd ...*rand.Rand) []T {
+	if n > len(values) {
+		n = len(values)
+	} else if n < 0 {
+		n = 0
+	}
+	return Shuffle(values, rnd...)[:n]
+}
+
+// Windows returns all windows of size consecutive elements, starting every step elements. Only complete windows are
+// returned. The windows share memory with the input slice. Size and step values below 1 are treated as 1.
+//
+// Example usage:
+//   s := []int{1, 2, 3, 4, 5}
+//   result := Windows(s, 3, 1)  // Output: [[1, 2, 3], [2, 3, 4], [3, 4, 5]]
+func Windows[T any](values []T, size, step int) [][]T {
+	if size < 1 {
+		size = 1
+	}
+	if step < 1 {
+		step = 1
+	}
+
+	windows := [][]T{}
+	for i := 0; i+size <= len(values); i += step {
+		windows = append(windows, values[i:i+size:i+size])
+	}
+	return windows
+}
+
+// SliceDiff describes the differences between two slices, see Diff.
+type SliceDiff[T any] struct {
+	Added     []T
+	Removed   []T
+	Unchanged []T
+}
+
+// Diff compares two slices. Elements only present in the new slice are reported as added, elements only present in
+// the old slice as removed and elements present in both as unchanged. Duplicates are matched one by one, so an element
+// occurring twice in the old and once in the new slice is reported as unchanged once and removed once. Added and
+// unchanged elements are ordered as in the new slice, removed elements as in the old slice.
+//
+// Example usage:
+//   old := []string{"a", "b", "c"}
+//   new := []string{"b", "c", "d"}
+//   result := Diff(old, new)  // Output: {Added: [d], Removed: [a], Unchanged: [b, c]}
+func Diff[T comparable](old, new []T) SliceDiff[T] {
+	diff := SliceDiff[T]{Added: []T{}, Removed: []T{}, Unchanged: []T{}}
+
+	remaining := map[T]int{}
+	for _, v := range old {
+		remaining[v]++
+	}
+	for _, v := range new {
+		if remaining[v] > 0 {
+			remaining[v]--
+			diff.Unchanged = append(diff.Unchanged, v)
+		} else {
+			diff.Added = append(diff.Added, v)
+		}
+	}
+	for _, v := range old {
+		if remaining[v] > 0 {
+			remaining[v]--
+			diff.Removed = append(diff.Removed, v)
+		}
+	}
+	return diff
+}
+
+// IndexBy returns a map from the key returned by keyFn to the element. If several elements share a key, the last
+// one wins.
+func IndexBy[T any, K comparable](values []T, keyFn func(T) K) map[K]T {
+	m := make(map[K]T, len(values))
+	for _, v := range values {
+		m[keyFn(v)] = v
+	}
+	return m
+}
+
+// ToMultiMap returns a map where each key holds all values returned by the generator function for that key, in the
+// order of the input elements. Unlike ToMap, duplicate keys are preserved. See GroupBy for grouping the elements
+// themselves.
+func ToMultiMap[T any, K comparable, V any](values []T, gen func(T) (K, V)) map[K][]V {
+	m := map[K][]V{}
+	for _, v := range values {
+		key, val := gen(v)
+		m[key] = append(m[key], val)
+	}
+	return m
+}
+
+// PageInfo describes a page returned by Paginate.
+type PageInfo struct {
+	Page       int
+	PerPage    int
+	Total      int
+	TotalPages int
+	HasPrev    bool
+	HasNext    bool
+}
+
+// Paginate returns the elements of the given page, counted from 1, along with information about the pagination.
+// Page and perPage values below 1 are treated as 1. A page beyond the last one results in an empty slice. The page
+// shares memory with the input slice.
+//
+// Example usage:
+//   s := []int{1, 2, 3, 4, 5}
+//   result, info := Paginate(s, 2, 2)  // Output: [3, 4], {Page: 2, PerPage: 2, Total: 5, TotalPages: 3, ...}
+func Paginate[T any](values []T, page, perPage int) ([]T, PageInfo) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 1
+	}
+
+	info := PageInfo{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      len(values),
+		TotalPages: (len(values) + perPage - 1) / perPage,
+	}
+	info.HasPrev = page > 1
+	info.HasNext = page < info.TotalPages
+
+	start := (page - 1) * perPage
+	if start >= len(values) {
+		return []T{}, info
+	}
+	end := start + perPage
+	if end > len(values) {
+		end = len(values)
+	}
+	return values[start:end:end], info
+}
@@ -0,0 +1,915 @@
+package tools
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsZero(t *testing.T) {
+	type withMutex struct {
+		mu    sync.Mutex
+		count int
+	}
+	type unexported struct {
+		name string
+		tags []string
+	}
+	type nested struct {
+		inner unexported
+		ptr   *int
+	}
+
+	zero, one := 0, 1
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  bool
+	}{
+		{name: "nil", value: nil, want: true},
+		{name: "empty string", value: "", want: true},
+		{name: "string", value: "x", want: false},
+		{name: "zero int", value: 0, want: true},
+		{name: "int", value: 1, want: false},
+		{name: "zero time", value: time.Time{}, want: true},
+		{name: "time", value: time.Unix(0, 0), want: false},
+		{name: "struct with mutex", value: &withMutex{}, want: true},
+		{name: "struct with mutex and value", value: &withMutex{count: 1}, want: false},
+		{name: "unexported fields", value: unexported{}, want: true},
+		{name: "unexported string field", value: unexported{name: "x"}, want: false},
+		{name: "unexported slice field", value: unexported{tags: []string{}}, want: false},
+		{name: "nested unexported", value: nested{inner: unexported{name: "x"}}, want: false},
+		{name: "nil pointer", value: (*int)(nil), want: true},
+		{name: "pointer to zero", value: &zero, want: true},
+		{name: "pointer to value", value: &one, want: false},
+		{name: "nested pointer to zero", value: nested{ptr: &zero}, want: true},
+		{name: "zero array", value: [2]int{}, want: true},
+		{name: "array", value: [2]int{0, 1}, want: false},
+		{name: "nil slice", value: []int(nil), want: true},
+		{name: "empty slice", value: []int{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZero(tt.value); got != tt.want {
+				t.Errorf("IsZero(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokensFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   TokenOptions
+		values []string
+		want   []string
+	}{
+		{name: "default", opts: TokenOptions{FoldCase: true}, values: []string{"b, A", "a c"}, want: []string{"b", "a", "c"}},
+		{name: "semicolon", opts: TokenOptions{Separators: ";"}, values: []string{"a b;c; d"}, want: []string{"a b", "c", "d"}},
+		{name: "case-sensitive dedup", values: []string{"Foo foo Foo"}, want: []string{"Foo", "foo"}},
+		{name: "case-insensitive dedup", opts: TokenOptions{FoldCase: true}, values: []string{"Foo foo"}, want: []string{"foo"}},
+		{
+			name:   "separator func",
+			opts:   TokenOptions{SeparatorFunc: func(r rune) bool { return r == '|' }},
+			values: []string{"a|b,c"},
+			want:   []string{"a", "b,c"},
+		},
+		{name: "trim quotes", opts: TokenOptions{TrimQuotes: true}, values: []string{`"a" 'b' "c'`}, want: []string{"a", "b", `"c'`}},
+		{name: "empty strings", values: []string{"", " , ", ""}, want: []string{}},
+		{name: "no values", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TokensFunc(tt.opts, tt.values...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TokensFunc() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := Tokens("B a,b"), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokens() = %q, want %q", got, want)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		target int
+		want   int
+		wantOK bool
+	}{
+		{name: "between closer to lower", values: []int{10, 20, 30}, target: 24, want: 20, wantOK: true},
+		{name: "between closer to upper", values: []int{10, 20, 30}, target: 26, want: 30, wantOK: true},
+		{name: "tie picks first", values: []int{30, 20, 10}, target: 25, want: 30, wantOK: true},
+		{name: "exact match", values: []int{10, 20, 30}, target: 20, want: 20, wantOK: true},
+		{name: "below all", values: []int{10, 20, 30}, target: -5, want: 10, wantOK: true},
+		{name: "above all", values: []int{10, 20, 30}, target: 100, want: 30, wantOK: true},
+		{name: "empty", values: nil, target: 5, want: 0, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Nearest(tt.values, tt.target)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Nearest(%v, %d) = %d, %v, want %d, %v", tt.values, tt.target, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if got, _ := Nearest([]float64{0.5, 1.5}, 1.4); got != 1.5 {
+		t.Errorf("Nearest(float64) = %v, want 1.5", got)
+	}
+	if got, _ := Nearest([]uint{3, 9}, 5); got != 3 {
+		t.Errorf("Nearest(uint) = %v, want 3", got)
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name   string
+		slices [][]int
+		want   []int
+	}{
+		{name: "unequal lengths", slices: [][]int{{1, 2, 3}, {4}, {5, 6}}, want: []int{1, 4, 5, 2, 6, 3}},
+		{name: "empty in the middle", slices: [][]int{{1, 2}, {}, {3, 4, 5}}, want: []int{1, 3, 2, 4, 5}},
+		{name: "single", slices: [][]int{{1, 2}}, want: []int{1, 2}},
+		{name: "none", slices: nil, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interleave(tt.slices...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Interleave(%v) = %v, want %v", tt.slices, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalList(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		sep    string
+		want   string
+	}{
+		{name: "plain", values: []string{"a", "b"}, sep: ",", want: "a,b"},
+		{name: "separator in value", values: []string{"a,b", "c"}, sep: ",", want: `a\,b,c`},
+		{name: "backslash in value", values: []string{`a\`, `\,`}, sep: ",", want: `a\\,\\\,`},
+		{name: "empty values", values: []string{"", "a", ""}, sep: ",", want: ",a,"},
+		{name: "multi-byte separator", values: []string{"a::b", "c:d"}, sep: "::", want: `a\::b::c:d`},
+		{name: "no values", values: []string{}, sep: ",", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := MarshalList(tt.values, tt.sep)
+			if s != tt.want {
+				t.Errorf("MarshalList(%q, %q) = %q, want %q", tt.values, tt.sep, s, tt.want)
+			}
+			if got := UnmarshalList(s, tt.sep); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("UnmarshalList(%q, %q) = %q, want %q", s, tt.sep, got, tt.values)
+			}
+		})
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{name: "several duplicates", values: []string{"a", "b", "b", "a", "c", "b"}, want: []string{"b", "a"}},
+		{name: "no duplicates", values: []string{"a", "b", "c"}, want: []string{}},
+		{name: "all the same", values: []string{"x", "x", "x"}, want: []string{"x"}},
+		{name: "empty", values: nil, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Duplicates(tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Duplicates(%q) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	isEmpty := func(s string) bool { return s == "" }
+
+	tests := []struct {
+		name       string
+		values     []string
+		wantBefore []string
+		wantAfter  []string
+		wantFound  bool
+	}{
+		{name: "middle", values: []string{"a", "b", "", "c"}, wantBefore: []string{"a", "b"}, wantAfter: []string{"c"}, wantFound: true},
+		{name: "start", values: []string{"", "a", "b"}, wantBefore: []string{}, wantAfter: []string{"a", "b"}, wantFound: true},
+		{name: "end", values: []string{"a", ""}, wantBefore: []string{"a"}, wantAfter: []string{}, wantFound: true},
+		{name: "first match only", values: []string{"a", "", "b", "", "c"}, wantBefore: []string{"a"}, wantAfter: []string{"b", "", "c"}, wantFound: true},
+		{name: "no match", values: []string{"a", "b"}, wantBefore: []string{"a", "b"}, wantAfter: nil, wantFound: false},
+		{name: "empty", values: nil, wantBefore: nil, wantAfter: nil, wantFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after, found := SplitAt(tt.values, isEmpty)
+			if !reflect.DeepEqual(before, tt.wantBefore) || !reflect.DeepEqual(after, tt.wantAfter) || found != tt.wantFound {
+				t.Errorf("SplitAt(%q) = %q, %q, %v, want %q, %q, %v",
+					tt.values, before, after, found, tt.wantBefore, tt.wantAfter, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		got, err := ParseList("1, 2 3,,2", strconv.Atoi)
+		if want := []int{1, 2, 3, 2}; err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseList() = %v, %v, want %v", got, err, want)
+		}
+	})
+
+	t.Run("durations", func(t *testing.T) {
+		got, err := ParseList("1s,90m 2h", time.ParseDuration)
+		if want := []time.Duration{time.Second, 90 * time.Minute, 2 * time.Hour}; err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseList() = %v, %v, want %v", got, err, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := ParseList(" , ", strconv.Atoi)
+		if err != nil || got == nil || len(got) != 0 {
+			t.Errorf("ParseList() = %v, %v, want an empty slice", got, err)
+		}
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		got, err := ParseList("1, x, 3", strconv.Atoi)
+		if err == nil {
+			t.Fatalf("ParseList() = %v, want an error", got)
+		}
+		if !strings.Contains(err.Error(), `"x"`) {
+			t.Errorf("ParseList() error %q does not name the invalid element", err)
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("ParseList() error %q does not wrap the parse error", err)
+		}
+	})
+}
+
+func TestMergeByKey(t *testing.T) {
+	type record struct {
+		ID  int
+		Rev int
+	}
+	key := func(r record) int { return r.ID }
+	newer := func(a, b record) bool { return b.Rev > a.Rev }
+
+	tests := []struct {
+		name    string
+		sources [][]record
+		want    []record
+	}{
+		{
+			name:    "second source better",
+			sources: [][]record{{{1, 1}, {2, 1}}, {{1, 2}, {3, 1}}},
+			want:    []record{{1, 2}, {2, 1}, {3, 1}},
+		},
+		{
+			name:    "first source better",
+			sources: [][]record{{{1, 3}, {2, 1}}, {{1, 2}}},
+			want:    []record{{1, 3}, {2, 1}},
+		},
+		{
+			name:    "duplicates within a source",
+			sources: [][]record{{{1, 1}, {1, 5}, {1, 2}}},
+			want:    []record{{1, 5}},
+		},
+		{
+			name:    "none",
+			sources: nil,
+			want:    []record{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeByKey(key, newer, tt.sources...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeByKey(%v) = %v, want %v", tt.sources, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   map[byte][]string
+	}{
+		{
+			name:   "groups keep order",
+			values: []string{"apple", "banana", "avocado", "blueberry", "cherry"},
+			want:   map[byte][]string{'a': {"apple", "avocado"}, 'b': {"banana", "blueberry"}, 'c': {"cherry"}},
+		},
+		{name: "empty", values: nil, want: map[byte][]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GroupBy(tt.values, func(s string) byte { return s[0] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupBy(%q) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("Reduce(sum) = %d, want 10", sum)
+	}
+
+	joined := Reduce([]int{1, 2, 3}, "", func(acc string, v int) string { return acc + strconv.Itoa(v) })
+	if joined != "123" {
+		t.Errorf("Reduce(join) = %q, want %q", joined, "123")
+	}
+
+	if got := Reduce(nil, 42, func(acc, v int) int { return acc + v }); got != 42 {
+		t.Errorf("Reduce(nil) = %d, want the initial value 42", got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		size   int
+		want   [][]int
+	}{
+		{name: "remainder", values: []int{1, 2, 3, 4, 5}, size: 2, want: [][]int{{1, 2}, {3, 4}, {5}}},
+		{name: "exact", values: []int{1, 2, 3, 4}, size: 2, want: [][]int{{1, 2}, {3, 4}}},
+		{name: "larger than slice", values: []int{1, 2}, size: 5, want: [][]int{{1, 2}}},
+		{name: "size below 1", values: []int{1, 2}, size: 0, want: [][]int{{1}, {2}}},
+		{name: "empty", values: nil, size: 3, want: [][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Chunk(tt.values, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chunk(%v, %d) = %v, want %v", tt.values, tt.size, got, tt.want)
+			}
+		})
+	}
+
+	// Appending to a chunk must not overwrite the following one.
+	values := []int{1, 2, 3, 4}
+	chunks := Chunk(values, 2)
+	_ = append(chunks[0], 99)
+	if values[2] != 3 {
+		t.Errorf("appending to a chunk modified the input: %v", values)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name        string
+		values      []int
+		wantMatched []int
+		wantRest    []int
+	}{
+		{name: "mixed", values: []int{1, 2, 3, 4, 5}, wantMatched: []int{2, 4}, wantRest: []int{1, 3, 5}},
+		{name: "all match", values: []int{2, 4}, wantMatched: []int{2, 4}, wantRest: []int{}},
+		{name: "empty", values: nil, wantMatched: []int{}, wantRest: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, rest := Partition(tt.values, isEven)
+			if !reflect.DeepEqual(matched, tt.wantMatched) || !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Errorf("Partition(%v) = %v, %v, want %v, %v", tt.values, matched, rest, tt.wantMatched, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     []string
+		b     []int
+		want  []Pair[string, int]
+		wantA []string
+		wantB []int
+	}{
+		{
+			name:  "equal length",
+			a:     []string{"a", "b"},
+			b:     []int{1, 2},
+			want:  []Pair[string, int]{{"a", 1}, {"b", 2}},
+			wantA: []string{"a", "b"},
+			wantB: []int{1, 2},
+		},
+		{
+			name:  "shorter second",
+			a:     []string{"a", "b", "c"},
+			b:     []int{1},
+			want:  []Pair[string, int]{{"a", 1}},
+			wantA: []string{"a"},
+			wantB: []int{1},
+		},
+		{name: "empty", a: nil, b: []int{1}, want: []Pair[string, int]{}, wantA: []string{}, wantB: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Zip(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Zip(%q, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			a, b := Unzip(got)
+			if !reflect.DeepEqual(a, tt.wantA) || !reflect.DeepEqual(b, tt.wantB) {
+				t.Errorf("Unzip(%v) = %q, %v, want %q, %v", got, a, b, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name   string
+		nested [][][]int
+		want   []int
+	}{
+		{name: "keeps duplicates", nested: [][][]int{{{1, 2}, {2, 3}}}, want: []int{1, 2, 2, 3}},
+		{name: "several", nested: [][][]int{{{1}}, {{}, {2, 3}}}, want: []int{1, 2, 3}},
+		{name: "empty", nested: nil, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Flatten(tt.nested...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Flatten(%v) = %v, want %v", tt.nested, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := FlatMap([]string{"a b", "", "c"}, strings.Fields), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlatMap() = %q, want %q", got, want)
+	}
+	if got := FlatMap(nil, strings.Fields); got == nil || len(got) != 0 {
+		t.Errorf("FlatMap(nil) = %#v, want an empty slice", got)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		slices [][]int
+		want   []int
+	}{
+		{name: "two", slices: [][]int{{1, 2, 3, 2}, {2, 3, 4}}, want: []int{2, 3}},
+		{name: "order of first", slices: [][]int{{3, 1, 2}, {1, 2, 3}}, want: []int{3, 1, 2}},
+		{name: "three", slices: [][]int{{1, 2, 3, 4}, {2, 3, 4}, {4, 3}}, want: []int{3, 4}},
+		{name: "disjoint", slices: [][]int{{1, 2}, {3}}, want: []int{}},
+		{name: "single", slices: [][]int{{1, 1, 2}}, want: []int{1, 2}},
+		{name: "none", slices: nil, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IntersectAll(tt.slices...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IntersectAll(%v) = %v, want %v", tt.slices, got, tt.want)
+			}
+			if len(tt.slices) == 2 {
+				if got := Intersect(tt.slices[0], tt.slices[1]); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Intersect(%v, %v) = %v, want %v", tt.slices[0], tt.slices[1], got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	values := []string{"ccc", "a", "bb", "d", "ee"}
+	original := append([]string{}, values...)
+
+	byLen := SortBy(values, func(s string) int { return len(s) })
+	if want := []string{"a", "d", "bb", "ee", "ccc"}; !reflect.DeepEqual(byLen, want) {
+		t.Errorf("SortBy(len) = %q, want %q", byLen, want)
+	}
+
+	desc := SortStableFunc(values, func(a, b string) bool { return len(a) > len(b) })
+	if want := []string{"ccc", "bb", "ee", "a", "d"}; !reflect.DeepEqual(desc, want) {
+		t.Errorf("SortStableFunc(len desc) = %q, want %q", desc, want)
+	}
+
+	if !reflect.DeepEqual(values, original) {
+		t.Errorf("sorting modified the input: %q", values)
+	}
+	if got := SortBy([]int{}, func(v int) int { return v }); got == nil || len(got) != 0 {
+		t.Errorf("SortBy(empty) = %#v, want an empty slice", got)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	original := append([]int{}, values...)
+
+	if got, want := Reverse(values), []int{8, 7, 6, 5, 4, 3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Reverse() = %v, want %v", got, want)
+	}
+
+	shuffled := Shuffle(values, rand.New(rand.NewSource(1)))
+	if again := Shuffle(values, rand.New(rand.NewSource(1))); !reflect.DeepEqual(shuffled, again) {
+		t.Errorf("Shuffle with the same seed returned %v and %v", shuffled, again)
+	}
+	if got := Sort(shuffled); !reflect.DeepEqual(got, values) {
+		t.Errorf("Shuffle() = %v, which is not a permutation of %v", shuffled, values)
+	}
+
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{n: 3, want: 3},
+		{n: 8, want: 8},
+		{n: 20, want: 8},
+		{n: 0, want: 0},
+		{n: -1, want: 0},
+	}
+	for _, tt := range tests {
+		sample := Sample(values, tt.n, rand.New(rand.NewSource(2)))
+		if len(sample) != tt.want {
+			t.Errorf("Sample(%d) returned %d elements, want %d", tt.n, len(sample), tt.want)
+		}
+		if len(Unique(sample)) != len(sample) {
+			t.Errorf("Sample(%d) = %v, picked an element twice", tt.n, sample)
+		}
+	}
+
+	if !reflect.DeepEqual(values, original) {
+		t.Errorf("Reverse, Shuffle or Sample modified the input: %v", values)
+	}
+}
+
+func TestWindows(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []int
+		size, step int
+		want       [][]int
+	}{
+		{name: "sliding", values: []int{1, 2, 3, 4, 5}, size: 3, step: 1, want: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{name: "step 2", values: []int{1, 2, 3, 4, 5}, size: 2, step: 2, want: [][]int{{1, 2}, {3, 4}}},
+		{name: "step larger than size", values: []int{1, 2, 3, 4, 5, 6}, size: 1, step: 3, want: [][]int{{1}, {4}}},
+		{name: "too short", values: []int{1, 2}, size: 3, step: 1, want: [][]int{}},
+		{name: "below 1", values: []int{1, 2}, size: 0, step: 0, want: [][]int{{1}, {2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Windows(tt.values, tt.size, tt.step); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Windows(%v, %d, %d) = %v, want %v", tt.values, tt.size, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new []string
+		want     SliceDiff[string]
+	}{
+		{
+			name: "added and removed",
+			old:  []string{"a", "b", "c"},
+			new:  []string{"b", "c", "d"},
+			want: SliceDiff[string]{Added: []string{"d"}, Removed: []string{"a"}, Unchanged: []string{"b", "c"}},
+		},
+		{
+			name: "duplicates",
+			old:  []string{"a", "a", "b"},
+			new:  []string{"b", "a", "b"},
+			want: SliceDiff[string]{Added: []string{"b"}, Removed: []string{"a"}, Unchanged: []string{"b", "a"}},
+		},
+		{
+			name: "equal",
+			old:  []string{"a"},
+			new:  []string{"a"},
+			want: SliceDiff[string]{Added: []string{}, Removed: []string{}, Unchanged: []string{"a"}},
+		},
+		{
+			name: "empty",
+			want: SliceDiff[string]{Added: []string{}, Removed: []string{}, Unchanged: []string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff(%q, %q) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregates(t *testing.T) {
+	tests := []struct {
+		name             string
+		values           []int
+		wantSum          int
+		wantAvg          float64
+		wantMin, wantMax int
+		wantOK           bool
+	}{
+		{name: "several", values: []int{3, 1, 4, 1, 5}, wantSum: 14, wantAvg: 2.8, wantMin: 1, wantMax: 5, wantOK: true},
+		{name: "negative", values: []int{-2, -7}, wantSum: -9, wantAvg: -4.5, wantMin: -7, wantMax: -2, wantOK: true},
+		{name: "single", values: []int{7}, wantSum: 7, wantAvg: 7, wantMin: 7, wantMax: 7, wantOK: true},
+		{name: "empty", values: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sum(tt.values); got != tt.wantSum {
+				t.Errorf("Sum(%v) = %d, want %d", tt.values, got, tt.wantSum)
+			}
+			if got := Avg(tt.values); got != tt.wantAvg {
+				t.Errorf("Avg(%v) = %v, want %v", tt.values, got, tt.wantAvg)
+			}
+			if min, max, ok := MinMax(tt.values); min != tt.wantMin || max != tt.wantMax || ok != tt.wantOK {
+				t.Errorf("MinMax(%v) = %d, %d, %v, want %d, %d, %v",
+					tt.values, min, max, ok, tt.wantMin, tt.wantMax, tt.wantOK)
+			}
+			if min, ok := Min(tt.values); min != tt.wantMin || ok != tt.wantOK {
+				t.Errorf("Min(%v) = %d, %v, want %d, %v", tt.values, min, ok, tt.wantMin, tt.wantOK)
+			}
+			if max, ok := Max(tt.values); max != tt.wantMax || ok != tt.wantOK {
+				t.Errorf("Max(%v) = %d, %v, want %d, %v", tt.values, max, ok, tt.wantMax, tt.wantOK)
+			}
+		})
+	}
+
+	if got := Avg([]uint8{200, 100}); got != 150 {
+		t.Errorf("Avg(uint8) = %v, want 150 without overflow", got)
+	}
+	if got := Sum([]float64{0.5, 0.25}); got != 0.75 {
+		t.Errorf("Sum(float64) = %v, want 0.75", got)
+	}
+	if min, _ := Min([]string{"b", "a", "c"}); min != "a" {
+		t.Errorf("Min(strings) = %q, want %q", min, "a")
+	}
+}
+
+func TestIndexBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Team string
+	}
+	users := []user{{1, "a"}, {2, "b"}, {3, "a"}, {1, "c"}}
+
+	byID := IndexBy(users, func(u user) int { return u.ID })
+	if want := map[int]user{1: {1, "c"}, 2: {2, "b"}, 3: {3, "a"}}; !reflect.DeepEqual(byID, want) {
+		t.Errorf("IndexBy() = %v, want %v", byID, want)
+	}
+
+	byTeam := ToMultiMap(users, func(u user) (string, int) { return u.Team, u.ID })
+	if want := map[string][]int{"a": {1, 3}, "b": {2}, "c": {1}}; !reflect.DeepEqual(byTeam, want) {
+		t.Errorf("ToMultiMap() = %v, want %v", byTeam, want)
+	}
+
+	if got := ToMultiMap(nil, func(u user) (string, int) { return u.Team, u.ID }); got == nil || len(got) != 0 {
+		t.Errorf("ToMultiMap(nil) = %#v, want an empty map", got)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name          string
+		page, perPage int
+		want          []int
+		wantInfo      PageInfo
+	}{
+		{
+			name: "first", page: 1, perPage: 2, want: []int{1, 2},
+			wantInfo: PageInfo{Page: 1, PerPage: 2, Total: 5, TotalPages: 3, HasPrev: false, HasNext: true},
+		},
+		{
+			name: "middle", page: 2, perPage: 2, want: []int{3, 4},
+			wantInfo: PageInfo{Page: 2, PerPage: 2, Total: 5, TotalPages: 3, HasPrev: true, HasNext: true},
+		},
+		{
+			name: "last partial", page: 3, perPage: 2, want: []int{5},
+			wantInfo: PageInfo{Page: 3, PerPage: 2, Total: 5, TotalPages: 3, HasPrev: true, HasNext: false},
+		},
+		{
+			name: "beyond last", page: 4, perPage: 2, want: []int{},
+			wantInfo: PageInfo{Page: 4, PerPage: 2, Total: 5, TotalPages: 3, HasPrev: true, HasNext: false},
+		},
+		{
+			name: "below 1", page: 0, perPage: 0, want: []int{1},
+			wantInfo: PageInfo{Page: 1, PerPage: 1, Total: 5, TotalPages: 5, HasPrev: false, HasNext: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, info := Paginate(values, tt.page, tt.perPage)
+			if !reflect.DeepEqual(got, tt.want) || info != tt.wantInfo {
+				t.Errorf("Paginate(%d, %d) = %v, %+v, want %v, %+v", tt.page, tt.perPage, got, info, tt.want, tt.wantInfo)
+			}
+		})
+	}
+
+	got, info := Paginate([]int{}, 1, 10)
+	if len(got) != 0 || info.TotalPages != 0 || info.HasNext || info.HasPrev {
+		t.Errorf("Paginate(empty) = %v, %+v", got, info)
+	}
+}
+
+func TestUniqueBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{name: "first wins", values: []string{"Apple", "apple", "Banana", "APPLE", "banana"}, want: []string{"Apple", "Banana"}},
+		{name: "no duplicates", values: []string{"a", "b"}, want: []string{"a", "b"}},
+		{name: "empty", values: []string{}, want: []string{}},
+		{name: "nil", values: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UniqueBy(tt.values, strings.ToLower); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UniqueBy(%q) = %#v, want %#v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	values := []int{1, 4, 6, 7, 8}
+	isEven := func(v int) bool { return v%2 == 0 }
+	isNegative := func(v int) bool { return v < 0 }
+
+	if v, ok := Find(values, isEven); v != 4 || !ok {
+		t.Errorf("Find(even) = %d, %v, want 4, true", v, ok)
+	}
+	if v, ok := FindLast(values, isEven); v != 8 || !ok {
+		t.Errorf("FindLast(even) = %d, %v, want 8, true", v, ok)
+	}
+	if i := FindIndex(values, isEven); i != 1 {
+		t.Errorf("FindIndex(even) = %d, want 1", i)
+	}
+	if v, ok := Find(values, isNegative); v != 0 || ok {
+		t.Errorf("Find(negative) = %d, %v, want 0, false", v, ok)
+	}
+	if v, ok := FindLast(values, isNegative); v != 0 || ok {
+		t.Errorf("FindLast(negative) = %d, %v, want 0, false", v, ok)
+	}
+	if i := FindIndex(values, isNegative); i != -1 {
+		t.Errorf("FindIndex(negative) = %d, want -1", i)
+	}
+
+	tests := []struct {
+		name    string
+		values  []int
+		wantAny bool
+		wantAll bool
+	}{
+		{name: "mixed", values: []int{1, 2}, wantAny: true, wantAll: false},
+		{name: "all", values: []int{2, 4}, wantAny: true, wantAll: true},
+		{name: "none", values: []int{1, 3}, wantAny: false, wantAll: false},
+		{name: "empty", values: nil, wantAny: false, wantAll: true},
+	}
+	for _, tt := range tests {
+		if got := Any(tt.values, isEven); got != tt.wantAny {
+			t.Errorf("Any(%v) = %v, want %v", tt.values, got, tt.wantAny)
+		}
+		if got := All(tt.values, isEven); got != tt.wantAll {
+			t.Errorf("All(%v) = %v, want %v", tt.values, got, tt.wantAll)
+		}
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	values := []int{10, 20, 20, 30}
+
+	tests := []struct {
+		target    int
+		want      int
+		wantFound bool
+	}{
+		{target: 5, want: 0, wantFound: false},
+		{target: 10, want: 0, wantFound: true},
+		{target: 20, want: 1, wantFound: true},
+		{target: 25, want: 3, wantFound: false},
+		{target: 30, want: 3, wantFound: true},
+		{target: 40, want: 4, wantFound: false},
+	}
+	for _, tt := range tests {
+		if i, found := SearchSorted(values, tt.target); i != tt.want || found != tt.wantFound {
+			t.Errorf("SearchSorted(%d) = %d, %v, want %d, %v", tt.target, i, found, tt.want, tt.wantFound)
+		}
+	}
+
+	type entry struct {
+		name string
+		age  int
+	}
+	entries := []entry{{"a", 18}, {"b", 30}, {"c", 45}}
+	byAge := func(e entry, age int) int { return e.age - age }
+	if i, found := SearchSortedFunc(entries, 30, byAge); i != 1 || !found {
+		t.Errorf("SearchSortedFunc(30) = %d, %v, want 1, true", i, found)
+	}
+	if i, found := SearchSortedFunc(entries, 40, byAge); i != 2 || found {
+		t.Errorf("SearchSortedFunc(40) = %d, %v, want 2, false", i, found)
+	}
+	if i, found := SearchSorted([]int(nil), 1); i != 0 || found {
+		t.Errorf("SearchSorted(nil) = %d, %v, want 0, false", i, found)
+	}
+}
+
+func TestTopN(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+		n      int
+		want   []int
+	}{
+		{name: "two", values: []int{5, 1, 9, 3, 7}, n: 2, want: []int{9, 7}},
+		{name: "duplicates", values: []int{5, 9, 1, 9}, n: 3, want: []int{9, 9, 5}},
+		{name: "more than available", values: []int{2, 1}, n: 5, want: []int{2, 1}},
+		{name: "zero", values: []int{2, 1}, n: 0, want: []int{}},
+		{name: "empty", values: nil, n: 2, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopN(tt.values, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TopN(%v, %d) = %v, want %v", tt.values, tt.n, got, tt.want)
+			}
+		})
+	}
+
+	words := []string{"a", "ccc", "bb", "dddd"}
+	if got, want := TopNBy(words, 2, func(s string) int { return len(s) }), []string{"dddd", "ccc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TopNBy(len) = %q, want %q", got, want)
+	}
+}
+
+func TestIsZeroCommonTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  bool
+	}{
+		{name: "nil", value: nil, want: true},
+		{name: "empty string", value: "", want: true},
+		{name: "string", value: "x", want: false},
+		{name: "false", value: false, want: true},
+		{name: "true", value: true, want: false},
+		{name: "int zero", value: 0, want: true},
+		{name: "int", value: -1, want: false},
+		{name: "int64", value: int64(5), want: false},
+		{name: "int32 zero", value: int32(0), want: true},
+		{name: "uint", value: uint(1), want: false},
+		{name: "uint64 zero", value: uint64(0), want: true},
+		{name: "uint32", value: uint32(3), want: false},
+		{name: "byte zero", value: byte(0), want: true},
+		{name: "float", value: 0.5, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZero(tt.value); got != tt.want {
+				t.Errorf("IsZero(%#v) = %v, want %v", tt.value, got, tt.want)
+			}
+			if got := IsNotZero(tt.value); got == tt.want {
+				t.Errorf("IsNotZero(%#v) = %v, want %v", tt.value, got, !tt.want)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name          string
+		a, b          []int
+		wantEqual     bool
+		wantUnordered bool
+	}{
+		{name: "same", a: []int{1, 2, 2}, b: []int{1, 2, 2}, wantEqual: true, wantUnordered: true},
+		{name: "reordered", a: []int{1, 2, 2}, b: []int{2, 1, 2}, wantEqual: false, wantUnordered: true},
+		{name: "different counts", a: []int{1, 2, 2}, b: []int{1, 1, 2}, wantEqual: false, wantUnordered: false},
+		{name: "different lengths", a: []int{1, 2}, b: []int{1, 2, 2}, wantEqual: false, wantUnordered: false},
+		{name: "nil and empty", a: nil, b: []int{}, wantEqual: true, wantUnordered: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.wantEqual {
+				t.Errorf("Equal(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.wantEqual)
+			}
+			if got := EqualUnordered(tt.a, tt.b); got != tt.wantUnordered {
+				t.Errorf("EqualUnordered(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.wantUnordered)
+			}
+		})
+	}
+}
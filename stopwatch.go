@@ -0,0 +1,59 @@
+package tools
+
+import "time"
+
+// Stopwatch measures elapsed time and laps. It is not safe for concurrent use.
+type Stopwatch struct {
+	clock Clock
+	start time.Time
+	lap   time.Time
+	laps  []time.Duration
+}
+
+// NewStopwatch returns a started stopwatch using the given clock. If clock is nil, SystemClock is used.
+func NewStopwatch(clock Clock) *Stopwatch {
+	if clock == nil {
+		clock = SystemClock
+	}
+	s := &Stopwatch{clock: clock}
+	s.Start()
+	return s
+}
+
+// Start (re)starts the stopwatch, discarding all laps.
+func (s *Stopwatch) Start() {
+	s.start = s.clock.Now()
+	s.lap = s.start
+	s.laps = nil
+}
+
+// Lap records and returns the time elapsed since the previous lap or, for the first lap, since the start.
+func (s *Stopwatch) Lap() time.Duration {
+	now := s.clock.Now()
+	d := now.Sub(s.lap)
+	s.lap = now
+	s.laps = append(s.laps, d)
+	return d
+}
+
+// Laps returns the durations of all recorded laps.
+func (s *Stopwatch) Laps() []time.Duration {
+	return append([]time.Duration{}, s.laps...)
+}
+
+// Elapsed returns the time elapsed since the start.
+func (s *Stopwatch) Elapsed() time.Duration {
+	return s.clock.Now().Sub(s.start)
+}
+
+// String returns the elapsed time formatted using FormatDuration.
+func (s *Stopwatch) String() string {
+	return FormatDuration(s.Elapsed())
+}
+
+// TimeIt calls f and returns how long it took. Use FormatDuration to print the result.
+func TimeIt(f func()) time.Duration {
+	start := time.Now()
+	f()
+	return time.Since(start)
+}
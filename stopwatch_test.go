@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStopwatch(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	s := NewStopwatch(c)
+
+	c.Advance(2 * time.Second)
+	if got := s.Lap(); got != 2*time.Second {
+		t.Errorf("Lap() = %v, want %v", got, 2*time.Second)
+	}
+	c.Advance(3 * time.Second)
+	if got := s.Lap(); got != 3*time.Second {
+		t.Errorf("Lap() = %v, want %v", got, 3*time.Second)
+	}
+	c.Advance(time.Second)
+
+	if got, want := s.Laps(), []time.Duration{2 * time.Second, 3 * time.Second}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Laps() = %v, want %v", got, want)
+	}
+	if got := s.Elapsed(); got != 6*time.Second {
+		t.Errorf("Elapsed() = %v, want %v", got, 6*time.Second)
+	}
+	if got := s.String(); got != "6s" {
+		t.Errorf("String() = %q, want %q", got, "6s")
+	}
+
+	s.Laps()[0] = 0
+	if got := s.Laps()[0]; got != 2*time.Second {
+		t.Error("Laps() returned the internal slice")
+	}
+
+	s.Start()
+	if got := s.Elapsed(); got != 0 {
+		t.Errorf("Elapsed() after Start() = %v, want 0", got)
+	}
+	if got := s.Laps(); len(got) != 0 {
+		t.Errorf("Laps() after Start() = %v, want none", got)
+	}
+}
+
+func TestTimeIt(t *testing.T) {
+	if got := TimeIt(func() { time.Sleep(10 * time.Millisecond) }); got < 10*time.Millisecond {
+		t.Errorf("TimeIt() = %v, want at least 10ms", got)
+	}
+	if s := NewStopwatch(nil); s.Elapsed() < 0 {
+		t.Error("NewStopwatch(nil) returned negative elapsed time")
+	}
+}
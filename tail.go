@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// TailOptions configures TailFile.
+type TailOptions struct {
+	// FromStart reads the file from the beginning instead of only reporting lines appended later.
+	FromStart bool
+
+	// PollInterval is the interval at which the file is checked for new data. It defaults to 250ms.
+	PollInterval time.Duration
+}
+
+// TailFile follows the given file like "tail -F" and calls fn for each new line, without the line terminator. If
+// the file is truncated, it is read again from the beginning. If it is replaced, e.g. by log rotation, the new file
+// is opened and read from the beginning once it exists. Following stops at the first error returned by fn or when
+// the context is canceled, in which case the context's error is returned.
+func TailFile(ctx context.Context, path string, opts TailOptions, fn func(line string) error) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = 250 * time.Millisecond
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	var next *os.File
+	defer func() {
+		f.Close()
+		if next != nil {
+			next.Close()
+		}
+	}()
+
+	var offset int64
+	if !opts.FromStart {
+		if offset, err = f.Seek(0, io.SeekEnd); err != nil {
+			return err
+		}
+	}
+
+	r := bufio.NewReader(f)
+	var partial strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		offset += int64(len(line))
+		partial.WriteString(line)
+		if err == nil {
+			if err = fn(strings.TrimRight(partial.String(), "\r\n")); err != nil {
+				return err
+			}
+			partial.Reset()
+			continue
+		}
+		if err != io.EOF {
+			return err
+		}
+
+		if next != nil {
+			// The old file has been read completely. Report an unterminated last line before switching over.
+			if partial.Len() > 0 {
+				if err = fn(strings.TrimRight(partial.String(), "\r")); err != nil {
+					return err
+				}
+				partial.Reset()
+			}
+			f.Close()
+			f, next, offset = next, nil, 0
+			r.Reset(f)
+			continue
+		}
+
+		if err = SleepContext(ctx, interval); err != nil {
+			return err
+		}
+
+		current, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		latest, err := os.Stat(path)
+		switch {
+		case err != nil && !os.IsNotExist(err):
+			return err
+		case err == nil && !os.SameFile(current, latest):
+			// The file was replaced. Data written to the old file in the meantime is read before switching over.
+			if next, err = os.Open(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		case current.Size() < offset:
+			// The file was truncated.
+			if _, err = f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			offset = 0
+			partial.Reset()
+			r.Reset(f)
+		}
+	}
+}
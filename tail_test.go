@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startTail runs TailFile in the background and returns the reported lines and the result of TailFile.
+func startTail(t *testing.T, path string, opts TailOptions) (<-chan string, <-chan error, context.CancelFunc) {
+	t.Helper()
+	opts.PollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := make(chan string, 100)
+	result := make(chan error, 1)
+	go func() {
+		result <- TailFile(ctx, path, opts, func(line string) error {
+			lines <- line
+			return nil
+		})
+	}()
+	t.Cleanup(cancel)
+	return lines, result, cancel
+}
+
+// waitLines waits for the given lines in order.
+func waitLines(t *testing.T, lines <-chan string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		select {
+		case got := <-lines:
+			if got != w {
+				t.Fatalf("TailFile() reported %q, want %q", got, w)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("TailFile() did not report %q", w)
+		}
+	}
+}
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err = f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTailFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "a\nb\n")
+
+	lines, _, _ := startTail(t, path, TailOptions{FromStart: true})
+	waitLines(t, lines, "a", "b")
+
+	appendFile(t, path, "c\r\nd")
+	waitLines(t, lines, "c")
+	time.Sleep(30 * time.Millisecond)
+	appendFile(t, path, "e\n")
+	waitLines(t, lines, "de")
+}
+
+func TestTailFileFromEnd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old\n")
+
+	lines, _, _ := startTail(t, path, TailOptions{})
+	// The initial seek may happen after the first append, so append until a line is reported.
+	deadline := time.After(5 * time.Second)
+	for {
+		appendFile(t, path, "new\n")
+		select {
+		case got := <-lines:
+			if got != "new" {
+				t.Fatalf("TailFile() reported %q, want %q", got, "new")
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("TailFile() did not report appended lines")
+		}
+	}
+}
+
+func TestTailFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "1\n")
+
+	lines, _, _ := startTail(t, path, TailOptions{FromStart: true})
+	waitLines(t, lines, "1")
+
+	// Data written to the old file after the rotation is read before switching over, including an unterminated
+	// last line.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path+".1", "2\nlast")
+	appendFile(t, path, "3\n")
+	waitLines(t, lines, "2", "last", "3")
+
+	appendFile(t, path, "4\n")
+	waitLines(t, lines, "4")
+}
+
+func TestTailFileRemovedAndRecreated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "1\n")
+
+	lines, _, _ := startTail(t, path, TailOptions{FromStart: true})
+	waitLines(t, lines, "1")
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	appendFile(t, path, "2\n")
+	waitLines(t, lines, "2")
+}
+
+func TestTailFileTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "aaaa\nbbbb\n")
+
+	lines, _, _ := startTail(t, path, TailOptions{FromStart: true})
+	waitLines(t, lines, "aaaa", "bbbb")
+
+	if err := os.WriteFile(path, []byte("c\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitLines(t, lines, "c")
+}
+
+func TestTailFileStop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "a\n")
+
+	_, result, cancel := startTail(t, path, TailOptions{})
+	cancel()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("TailFile() returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("TailFile() did not return after cancellation")
+	}
+
+	errStop := errors.New("stop")
+	err := TailFile(context.Background(), path, TailOptions{FromStart: true}, func(string) error { return errStop })
+	if !errors.Is(err, errStop) {
+		t.Errorf("TailFile() returned %v, want %v", err, errStop)
+	}
+
+	if err := TailFile(context.Background(), path+".missing", TailOptions{}, nil); !os.IsNotExist(err) {
+		t.Errorf("TailFile() of a missing file returned %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"os"
+	"sync"
+)
+
+// ManagedFile is a temporary file that is removed when the program terminates via Exit, unless Keep is called.
+type ManagedFile struct {
+	*os.File
+	cancel func()
+	once   sync.Once
+}
+
+// TempFileManaged creates a temporary file like os.CreateTemp in the default directory for temporary files and
+// registers its removal with AtExit.
+func TempFileManaged(pattern string) (*ManagedFile, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ManagedFile{File: f}
+	m.cancel = AtExit(func() {
+		f.Close()
+		os.Remove(f.Name())
+	})
+	return m, nil
+}
+
+// Keep cancels the removal of the file on exit.
+func (m *ManagedFile) Keep() {
+	m.once.Do(m.cancel)
+}
+
+// Remove closes and removes the file right away.
+func (m *ManagedFile) Remove() error {
+	m.Keep()
+	m.File.Close()
+	return os.Remove(m.Name())
+}
+
+// ManagedDir is a temporary directory that is removed with all its content when the program terminates via Exit,
+// unless Keep is called.
+type ManagedDir struct {
+	// Path is the path of the directory.
+	Path string
+
+	cancel func()
+	once   sync.Once
+}
+
+// TempDirManaged creates a temporary directory like os.MkdirTemp in the default directory for temporary files and
+// registers its removal with AtExit.
+func TempDirManaged(pattern string) (*ManagedDir, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &ManagedDir{Path: dir}
+	m.cancel = AtExit(func() {
+		os.RemoveAll(dir)
+	})
+	return m, nil
+}
+
+// Keep cancels the removal of the directory on exit.
+func (m *ManagedDir) Keep() {
+	m.once.Do(m.cancel)
+}
+
+// Remove removes the directory and its content right away.
+func (m *ManagedDir) Remove() error {
+	m.Keep()
+	return os.RemoveAll(m.Path)
+}
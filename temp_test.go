@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTempFileManaged(t *testing.T) {
+	_, code := captureExit(t)
+
+	removed, err := TempFileManaged("managed-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removed.Keep()
+	if _, err := removed.WriteString("data"); err != nil {
+		t.Fatal(err)
+	}
+
+	kept, err := TempFileManaged("managed-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(kept.Name())
+	kept.Keep()
+	kept.Close()
+
+	early, err := TempFileManaged("managed-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := early.Remove(); err != nil {
+		t.Errorf("Remove() failed: %v", err)
+	}
+	if _, err := os.Stat(early.Name()); !os.IsNotExist(err) {
+		t.Errorf("Remove() left %s in place", early.Name())
+	}
+
+	Exit(3)
+	if *code != 3 {
+		t.Errorf("exit code = %d, want 3", *code)
+	}
+	if _, err := os.Stat(removed.Name()); !os.IsNotExist(err) {
+		t.Errorf("Exit() did not remove %s", removed.Name())
+	}
+	if _, err := os.Stat(kept.Name()); err != nil {
+		t.Errorf("Exit() removed %s after Keep()", kept.Name())
+	}
+}
+
+func TestTempDirManaged(t *testing.T) {
+	captureExit(t)
+
+	removed, err := TempDirManaged("managed-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removed.Keep()
+	makeTree(t, removed.Path, []string{"sub/file.txt"}, nil)
+
+	kept, err := TempDirManaged("managed-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(kept.Path)
+	kept.Keep()
+
+	early, err := TempDirManaged("managed-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	makeTree(t, early.Path, []string{"file.txt"}, nil)
+	if err := early.Remove(); err != nil {
+		t.Errorf("Remove() failed: %v", err)
+	}
+	if _, err := os.Stat(early.Path); !os.IsNotExist(err) {
+		t.Errorf("Remove() left %s in place", early.Path)
+	}
+
+	Exit(0)
+	if _, err := os.Stat(filepath.Join(removed.Path, "sub")); !os.IsNotExist(err) {
+		t.Errorf("Exit() did not remove %s", removed.Path)
+	}
+	if _, err := os.Stat(kept.Path); err != nil {
+		t.Errorf("Exit() removed %s after Keep()", kept.Path)
+	}
+}
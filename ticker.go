@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// JitterTicker delivers ticks at randomized intervals around a base interval, which keeps many processes started at
+// the same time from acting in lockstep.
+type JitterTicker struct {
+	// C receives the ticks. Like with time.Ticker, ticks are dropped if the receiver falls behind.
+	C <-chan time.Time
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewJitterTicker returns a ticker whose intervals vary randomly by up to jitterFraction of the base interval in
+// either direction, e.g. 0.1 for ±10%. The fraction is limited to the range from 0 to 1. The base interval must be
+// positive, otherwise NewJitterTicker panics like time.NewTicker.
+func NewJitterTicker(base time.Duration, jitterFraction float64) *JitterTicker {
+	if base <= 0 {
+		panic("non-positive interval for NewJitterTicker")
+	}
+	if !(jitterFraction >= 0) {
+		// This also catches NaN.
+		jitterFraction = 0
+	} else if jitterFraction > 1 {
+		jitterFraction = 1
+	}
+
+	c := make(chan time.Time, 1)
+	t := &JitterTicker{C: c, done: make(chan struct{})}
+
+	go func() {
+		timer := time.NewTimer(jitter(base, jitterFraction))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-t.done:
+				return
+			case now := <-timer.C:
+				select {
+				case c <- now:
+				default:
+				}
+				timer.Reset(jitter(base, jitterFraction))
+			}
+		}
+	}()
+	return t
+}
+
+// Stop turns off the ticker. A tick already waiting in C may still be received.
+func (t *JitterTicker) Stop() {
+	t.once.Do(func() {
+		close(t.done)
+	})
+}
+
+// jitter returns d randomly varied by up to the given fraction in either direction.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	return d + time.Duration(float64(d)*fraction*(2*rand.Float64()-1))
+}
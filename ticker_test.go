@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewJitterTickerPanics(t *testing.T) {
+	for _, base := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewJitterTicker(%v) did not panic", base)
+				}
+			}()
+			NewJitterTicker(base, 0.1).Stop()
+		}()
+	}
+}
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		fraction float64
+		min, max time.Duration
+	}{
+		{fraction: 0, min: time.Second, max: time.Second},
+		{fraction: 0.1, min: 900 * time.Millisecond, max: 1100 * time.Millisecond},
+		{fraction: 1, min: 0, max: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 1000; i++ {
+			if d := jitter(time.Second, tt.fraction); d < tt.min || d > tt.max {
+				t.Fatalf("jitter(1s, %v) = %v, want within [%v, %v]", tt.fraction, d, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestJitterTicker(t *testing.T) {
+	for _, fraction := range []float64{-1, 0.5, 2, math.NaN()} {
+		ticker := NewJitterTicker(10*time.Millisecond, fraction)
+		select {
+		case <-ticker.C:
+		case <-time.After(time.Second):
+			t.Errorf("NewJitterTicker(10ms, %v) did not tick", fraction)
+		}
+		ticker.Stop()
+		ticker.Stop()
+	}
+}
@@ -1,7 +1,9 @@
 package tools
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -12,14 +14,44 @@ var (
 	// Regex patterns for duration units and for checking validity of duration units.
 	reDurationUnit       = regexp.MustCompile(`(\d+(?:\.\d+)?)([a-zA-Zµ]+)`)
 	reValidDurationUnits = regexp.MustCompile("^[+-]?(" + reDurationUnit.String() + ")+$")
+
+	// Regex pattern for ISO 8601 durations, matched against the lower-cased input.
+	reISODuration = regexp.MustCompile(`^([+-])?p(?:(\d+(?:[.,]\d+)?)y)?(?:(\d+(?:[.,]\d+)?)m)?(?:(\d+(?:[.,]\d+)?)w)?` +
+		`(?:(\d+(?:[.,]\d+)?)d)?(?:t(?:(\d+(?:[.,]\d+)?)h)?(?:(\d+(?:[.,]\d+)?)m)?(?:(\d+(?:[.,]\d+)?)s)?)?$`)
 )
 
+// isoDurationUnits lists the units of the components matched by reISODuration, in order.
+var isoDurationUnits = []time.Duration{
+	time.Hour * 24 * 365,
+	time.Hour * 24 * 30,
+	time.Hour * 24 * 7,
+	time.Hour * 24,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// addDurationPart adds value times unit to the non-negative duration total. It reports false if the result does not
+// fit into a time.Duration.
+func addDurationPart(total time.Duration, value float64, unit time.Duration) (time.Duration, bool) {
+	d := value * float64(unit)
+	if d >= math.MaxInt64 || time.Duration(d) > math.MaxInt64-total {
+		return 0, false
+	}
+	return total + time.Duration(d), true
+}
+
 // ParseDuration takes a string representing a duration and returns its equivalent time.Duration.
-// It supports different units like seconds, minutes, hours, days, weeks and years.
+// It supports different units like seconds, minutes, hours, days, weeks and years. ISO 8601 durations like
+// "P1DT2H30M" are supported as well, see ParseDurationISO.
 func ParseDuration(input string) (time.Duration, error) {
 	// Remove all whitespace and lowercase the given duration
 	cleaned := strings.ToLower(strings.Join(strings.Fields(input), ""))
 
+	if strings.HasPrefix(strings.TrimLeft(cleaned, "+-"), "p") {
+		return ParseDurationISO(input)
+	}
+
 	// Check if the cleaned duration string is a valid duration
 	if !reValidDurationUnits.MatchString(cleaned) {
 		return 0, fmt.Errorf("invalid duration: %q", input)
@@ -61,7 +93,10 @@ func ParseDuration(input string) (time.Duration, error) {
 			return 0, fmt.Errorf("invalid unit %q in duration", unit)
 		}
 
-		total += time.Duration(value * float64(d))
+		var ok bool
+		if total, ok = addDurationPart(total, value, d); !ok {
+			return 0, fmt.Errorf("duration out of range: %q", input)
+		}
 	}
 
 	if strings.HasPrefix(cleaned, "-") {
@@ -71,6 +106,100 @@ func ParseDuration(input string) (time.Duration, error) {
 	return total, nil
 }
 
+// ParseDurationISO parses an ISO 8601 duration like "P1Y2M3DT4H5M6.5S" or "P2W". Like ParseDuration, it counts a
+// year as 365 days. A month is counted as 30 days. A leading sign is allowed to express negative durations.
+func ParseDurationISO(input string) (time.Duration, error) {
+	// Remove all whitespace and lowercase the given duration
+	cleaned := strings.ToLower(strings.Join(strings.Fields(input), ""))
+
+	match := reISODuration.FindStringSubmatch(cleaned)
+	if match == nil || strings.HasSuffix(cleaned, "t") || strings.Join(match[2:], "") == "" {
+		return 0, fmt.Errorf("invalid ISO 8601 duration: %q", input)
+	}
+
+	var total time.Duration
+	for i, part := range match[2:] {
+		if part == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number in duration: %v", err)
+		}
+		var ok bool
+		if total, ok = addDurationPart(total, value, isoDurationUnits[i]); !ok {
+			return 0, fmt.Errorf("ISO 8601 duration out of range: %q", input)
+		}
+	}
+
+	if match[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
+
+// ParseDurationFrom is similar to ParseDuration but uses calendar arithmetic relative to the reference time for years
+// and months, which are supported as "mo", "mon", "month" and "months". For example, "1mo" starting on February 1st
+// is 28 or 29 days. See AddDuration.
+func ParseDurationFrom(input string, ref time.Time) (time.Duration, error) {
+	t, err := AddDuration(ref, input)
+	if err != nil {
+		return 0, err
+	}
+	return t.Sub(ref), nil
+}
+
+// AddDuration adds a duration in the format of ParseDuration to the given time. Years and months are added using
+// calendar arithmetic like time.AddDate, so "1y" always advances to the same date in the following year. Fractions of
+// years and months are based on the length of the year or month following the whole ones.
+func AddDuration(t time.Time, input string) (time.Time, error) {
+	// Remove all whitespace and lowercase the given duration
+	cleaned := strings.ToLower(strings.Join(strings.Fields(input), ""))
+
+	if !reValidDurationUnits.MatchString(cleaned) {
+		return t, fmt.Errorf("invalid duration: %q", input)
+	}
+
+	sign := 1
+	if strings.HasPrefix(cleaned, "-") {
+		sign = -1
+	}
+
+	var fixed []string
+	for _, match := range reDurationUnit.FindAllStringSubmatch(cleaned, -1) {
+		var years, months int
+		switch match[2] {
+		case "y", "yr", "yrs", "year", "years":
+			years = 1
+		case "mo", "mon", "month", "months":
+			months = 1
+		default:
+			fixed = append(fixed, match[0])
+			continue
+		}
+
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return t, fmt.Errorf("invalid number in duration: %v", err)
+		}
+		whole := int(value)
+		t = t.AddDate(sign*whole*years, sign*whole*months, 0)
+		if frac := value - float64(whole); frac > 0 {
+			next := t.AddDate(sign*years, sign*months, 0)
+			t = t.Add(time.Duration(frac * float64(next.Sub(t))))
+		}
+	}
+
+	if len(fixed) > 0 {
+		d, err := ParseDuration(strings.Join(fixed, ""))
+		if err != nil {
+			return t, err
+		}
+		t = t.Add(time.Duration(sign) * d)
+	}
+	return t, nil
+}
+
 // ParseDurationWithDefaultUnit is similar to ParseDuration but it accepts a default unit.
 // If the input string is a simple float, it assumes the default unit.
 func ParseDurationWithDefaultUnit(input, defaultUnit string) (time.Duration, error) {
@@ -87,6 +216,45 @@ func ParseDurationWithDefaultUnit(input, defaultUnit string) (time.Duration, err
 	return ParseDuration(cleaned + defaultUnit)
 }
 
+// ClampDuration limits d to the range [min, max].
+func ClampDuration(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// MinDuration returns the shortest of the given durations.
+func MinDuration(d time.Duration, more ...time.Duration) time.Duration {
+	for _, v := range more {
+		if v < d {
+			d = v
+		}
+	}
+	return d
+}
+
+// MaxDuration returns the longest of the given durations.
+func MaxDuration(d time.Duration, more ...time.Duration) time.Duration {
+	for _, v := range more {
+		if v > d {
+			d = v
+		}
+	}
+	return d
+}
+
+// DurationPercent returns part as a percentage of total. It returns 0 if total is 0.
+func DurationPercent(part, total time.Duration) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
 // FormatDuration takes a time.Duration and formats it as a string in the format used by ParseDuration.
 // The format is the largest suitable unit followed by the next largest, and so on.
 // For example, 25 hours 90 seconds is formatted as "1d1h1m30s".
@@ -101,15 +269,9 @@ func FormatDuration(d time.Duration) string {
 		parts = append(parts, "-")
 	}
 
-	// Break down the duration into years, weeks, days, hours, minutes, and seconds
-	years := int(d.Hours() / 24 / 365)
-	d -= time.Duration(years) * 365 * 24 * time.Hour
-
-	weeks := int(d.Hours() / 24 / 7)
-	d -= time.Duration(weeks) * 7 * 24 * time.Hour
-
-	days := int(d.Hours() / 24)
-	d -= time.Duration(days) * 24 * time.Hour
+	// Break down the duration into years, weeks, days and the remainder
+	years, weeks, days, _, _, _, _ := DurationParts(d)
+	d %= 24 * time.Hour
 
 	// Construct the string representation using the largest units possible
 	if years > 0 {
@@ -139,3 +301,379 @@ func FormatDuration(d time.Duration) string {
 	// Join all the parts with no separator
 	return strings.Join(parts, "")
 }
+
+// DurationParts breaks down the given duration into years, weeks, days, hours, minutes, seconds and nanoseconds,
+// using the same units as FormatDuration: a year is 365 days and a week is 7 days. For negative durations, all
+// components are negative or zero.
+func DurationParts(d time.Duration) (years, weeks, days, hours, minutes, seconds, nanos int) {
+	const (
+		day  = 24 * time.Hour
+		week = 7 * day
+		year = 365 * day
+	)
+
+	years, d = int(d/year), d%year
+	weeks, d = int(d/week), d%week
+	days, d = int(d/day), d%day
+	hours, d = int(d/time.Hour), d%time.Hour
+	minutes, d = int(d/time.Minute), d%time.Minute
+	seconds, d = int(d/time.Second), d%time.Second
+	nanos = int(d)
+	return
+}
+
+// HumanDurationOptions controls the output of FormatDurationHuman.
+type HumanDurationOptions struct {
+	// Compact uses unit abbreviations like "2d 3h" instead of "2 days, 3 hours".
+	Compact bool
+
+	// MaxUnits limits the output to the given number of the largest non-zero units. Zero means no limit.
+	MaxUnits int
+
+	// Separator is put between units. It defaults to " " in compact mode and ", " otherwise.
+	Separator string
+
+	// Round rounds to the smallest unit included in the output instead of truncating.
+	Round bool
+}
+
+// humanUnits lists the units used by FormatDurationHuman, from largest to smallest.
+var humanUnits = []struct {
+	d            time.Duration
+	name, abbrev string
+}{
+	{time.Hour * 24 * 365, "year", "y"},
+	{time.Hour * 24 * 7, "week", "w"},
+	{time.Hour * 24, "day", "d"},
+	{time.Hour, "hour", "h"},
+	{time.Minute, "minute", "m"},
+	{time.Second, "second", "s"},
+	{time.Millisecond, "millisecond", "ms"},
+}
+
+// FormatDurationHuman formats a duration for humans, e.g. "2 days, 3 hours" or, in compact mode, "2d 3h". Units
+// range from years to milliseconds, and units with a value of zero are omitted.
+func FormatDurationHuman(d time.Duration, opts HumanDurationOptions) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = ", "
+		if opts.Compact {
+			sep = " "
+		}
+	}
+
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	// The smallest unit is the last one to show, either due to MaxUnits or because it is the smallest unit at all.
+	smallest := len(humanUnits) - 1
+	if opts.MaxUnits > 0 {
+		for i, u := range humanUnits {
+			if d >= u.d || i == smallest {
+				if i+opts.MaxUnits-1 < smallest {
+					smallest = i + opts.MaxUnits - 1
+				}
+				break
+			}
+		}
+	}
+
+	// Round or truncate the remainder below the larger units. Years are no multiple of weeks, so the whole duration
+	// cannot be rounded directly.
+	var larger time.Duration
+	for _, u := range humanUnits[:smallest] {
+		larger += (d - larger) / u.d * u.d
+	}
+	if unit := humanUnits[smallest].d; opts.Round {
+		d = larger + (d - larger).Round(unit)
+	} else {
+		d = larger + (d - larger).Truncate(unit)
+	}
+
+	parts := []string{}
+	for _, u := range humanUnits {
+		n := int64(d / u.d)
+		d %= u.d
+		if n == 0 || (opts.MaxUnits > 0 && len(parts) == opts.MaxUnits) {
+			continue
+		}
+
+		switch {
+		case opts.Compact:
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.abbrev))
+		case n == 1:
+			parts = append(parts, fmt.Sprintf("%d %s", n, u.name))
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", n, u.name))
+		}
+	}
+
+	if len(parts) == 0 {
+		if opts.Compact {
+			return "0s"
+		}
+		return "0 seconds"
+	}
+	return sign + strings.Join(parts, sep)
+}
+
+// RelTimeOptions controls the output of RelTimeWith.
+type RelTimeOptions struct {
+	// JustNow is the distance below which "just now" is returned.
+	JustNow time.Duration
+
+	// Duration controls how the distance between the times is formatted.
+	Duration HumanDurationOptions
+}
+
+// DefaultRelTimeOptions are the options used by RelTime.
+var DefaultRelTimeOptions = RelTimeOptions{
+	JustNow:  10 * time.Second,
+	Duration: HumanDurationOptions{MaxUnits: 1, Round: true},
+}
+
+// RelTime describes t relative to now, e.g. "3 days ago", "in 2 hours" or "just now", see DefaultRelTimeOptions.
+func RelTime(t, now time.Time) string {
+	return RelTimeWith(t, now, DefaultRelTimeOptions)
+}
+
+// RelTimeWith describes t relative to now using the given options.
+func RelTimeWith(t, now time.Time, opts RelTimeOptions) string {
+	d := t.Sub(now)
+	if d < 0 {
+		d = -d
+	}
+	if d < opts.JustNow || d == 0 {
+		return "just now"
+	}
+
+	s := FormatDurationHuman(d, opts.Duration)
+	if t.Before(now) {
+		return s + " ago"
+	}
+	return "in " + s
+}
+
+// TimeLayouts lists the layouts tried by ParseTime, in order.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.UnixDate,
+	time.RubyDate,
+	time.ANSIC,
+	time.StampNano,
+	"02/Jan/2006:15:04:05 -0700",
+}
+
+// reEpoch matches Unix timestamps, optionally with a fraction. At least 9 digits are required, so that compact
+// dates like "20240102" are not mistaken for seconds in 1970.
+var reEpoch = regexp.MustCompile(`^[+-]?\d{9,}(\.\d+)?$`)
+
+// ParseTime parses a timestamp trying each of TimeLayouts in turn. Timestamps without a time zone are interpreted
+// as UTC. Unix timestamps with at least 9 digits, i.e. from 1973 on, are supported as well, see FromUnixAny. See
+// ParseTimeInLocation for supported time zones.
+func ParseTime(input string) (time.Time, error) {
+	return ParseTimeInLocation(input, time.UTC)
+}
+
+// ParseTimeInLocation works like ParseTime but interprets timestamps without a time zone in the given location.
+// Time zone abbreviations listed in TimeZoneAbbreviations are recognized, and a timestamp may be followed by anything
+// ResolveLocation understands, e.g. "2024-01-02 15:04 Europe/Berlin" or "2024-01-02 15:04 UTC+2".
+func ParseTimeInLocation(input string, loc *time.Location) (time.Time, error) {
+	s := strings.TrimSpace(input)
+
+	if reEpoch.MatchString(s) {
+		t, err := FromUnixAny(s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp: %q", input)
+		}
+		return t.In(loc), nil
+	}
+
+	for _, layout := range TimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return fixZoneAbbreviation(t), nil
+		}
+	}
+
+	// Try a trailing time zone
+	if i := strings.LastIndexAny(s, " \t"); i > 0 {
+		if zone, err := ResolveLocation(s[i+1:]); err == nil {
+			rest := strings.TrimSpace(s[:i])
+			for _, layout := range TimeLayouts {
+				if t, err := time.ParseInLocation(layout, rest, zone); err == nil {
+					return t, nil
+				}
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp: %q", input)
+}
+
+// FromUnixAny converts a Unix timestamp to a time. The timestamp may be any integer or floating point type, a
+// json.Number or a string. Its precision is detected from its magnitude: absolute values below 1e11 are taken as
+// seconds, below 1e14 as milliseconds, below 1e17 as microseconds and everything else as nanoseconds. This covers
+// dates between 1973 and 5138.
+func FromUnixAny(v interface{}) (time.Time, error) {
+	var s string
+	switch n := v.(type) {
+	case int:
+		return fromUnixInt(int64(n)), nil
+	case int8:
+		return fromUnixInt(int64(n)), nil
+	case int16:
+		return fromUnixInt(int64(n)), nil
+	case int32:
+		return fromUnixInt(int64(n)), nil
+	case int64:
+		return fromUnixInt(n), nil
+	case uint:
+		return fromUnixUint(uint64(n))
+	case uint8:
+		return fromUnixInt(int64(n)), nil
+	case uint16:
+		return fromUnixInt(int64(n)), nil
+	case uint32:
+		return fromUnixInt(int64(n)), nil
+	case uint64:
+		return fromUnixUint(n)
+	case float32:
+		return fromUnixFloat(float64(n))
+	case float64:
+		return fromUnixFloat(n)
+	case json.Number:
+		s = n.String()
+	case string:
+		s = n
+	default:
+		return time.Time{}, fmt.Errorf("unsupported timestamp type %T", v)
+	}
+
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return fromUnixInt(n), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %q", s)
+	}
+	return fromUnixFloat(f)
+}
+
+func fromUnixInt(n int64) time.Time {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(n, 0)
+	case abs < 1e14:
+		return time.UnixMilli(n)
+	case abs < 1e17:
+		return time.UnixMicro(n)
+	}
+	return time.Unix(0, n)
+}
+
+func fromUnixUint(n uint64) (time.Time, error) {
+	if n > math.MaxInt64 {
+		return time.Time{}, fmt.Errorf("timestamp out of range: %d", n)
+	}
+	return fromUnixInt(int64(n)), nil
+}
+
+func fromUnixFloat(f float64) (time.Time, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || math.Abs(f) >= math.MaxInt64 {
+		return time.Time{}, fmt.Errorf("timestamp out of range: %v", f)
+	}
+
+	abs := math.Abs(f)
+	switch {
+	case abs < 1e11:
+	case abs < 1e14:
+		f /= 1e3
+	case abs < 1e17:
+		f /= 1e6
+	default:
+		f /= 1e9
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))), nil
+}
+
+// StartOfDay returns midnight at the start of t's day in t's location.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfWeek returns the start of t's week, which begins on Monday.
+func StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfMonth returns the start of t's month.
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfQuarter returns the start of t's quarter.
+func StartOfQuarter(t time.Time) time.Time {
+	month := (t.Month()-1)/3*3 + 1
+	return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+}
+
+// StartOfYear returns the start of t's year.
+func StartOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay returns the last nanosecond of t's day.
+func EndOfDay(t time.Time) time.Time {
+	return StartOfDay(t).AddDate(0, 0, 1).Add(-1)
+}
+
+// EndOfWeek returns the last nanosecond of t's week, which ends on Sunday.
+func EndOfWeek(t time.Time) time.Time {
+	return StartOfWeek(t).AddDate(0, 0, 7).Add(-1)
+}
+
+// EndOfMonth returns the last nanosecond of t's month.
+func EndOfMonth(t time.Time) time.Time {
+	return StartOfMonth(t).AddDate(0, 1, 0).Add(-1)
+}
+
+// EndOfQuarter returns the last nanosecond of t's quarter.
+func EndOfQuarter(t time.Time) time.Time {
+	return StartOfQuarter(t).AddDate(0, 3, 0).Add(-1)
+}
+
+// EndOfYear returns the last nanosecond of t's year.
+func EndOfYear(t time.Time) time.Time {
+	return StartOfYear(t).AddDate(1, 0, 0).Add(-1)
+}
+
+// TruncateTo rounds t down to a multiple of d like time.Time.Truncate, but relative to midnight in t's location
+// instead of UTC, so truncating to 6h in UTC+2 results in 00:00, 06:00, 12:00 or 18:00 local time.
+func TruncateTo(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(d).Add(-shift).In(t.Location())
+}
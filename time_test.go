@@ -0,0 +1,434 @@
+package tools
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDurationParts(t *testing.T) {
+	const (
+		day  = 24 * time.Hour
+		week = 7 * day
+		year = 365 * day
+	)
+	type parts [7]int
+
+	tests := []struct {
+		name string
+		d    time.Duration
+		want parts
+	}{
+		{name: "zero", d: 0, want: parts{}},
+		{
+			name: "all units",
+			d:    2*year + 3*week + 4*day + 5*time.Hour + 6*time.Minute + 7*time.Second + 8,
+			want: parts{2, 3, 4, 5, 6, 7, 8},
+		},
+		{name: "days and minutes", d: 10*day + 90*time.Minute, want: parts{0, 1, 3, 1, 30, 0, 0}},
+		{name: "sub-second", d: 1500 * time.Millisecond, want: parts{0, 0, 0, 0, 0, 1, 500000000}},
+		{name: "negative", d: -(day + 2*time.Second), want: parts{0, 0, -1, 0, 0, -2, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got parts
+			got[0], got[1], got[2], got[3], got[4], got[5], got[6] = DurationParts(tt.d)
+			if got != tt.want {
+				t.Errorf("DurationParts(%v) = %v, want %v", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDurationISO(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "P1DT2H30M", want: 26*time.Hour + 30*time.Minute},
+		{input: "P2W", want: 14 * 24 * time.Hour},
+		{input: "P1Y", want: 365 * 24 * time.Hour},
+		{input: "P1M", want: 30 * 24 * time.Hour},
+		{input: "PT1M", want: time.Minute},
+		{input: "-PT1.5S", want: -1500 * time.Millisecond},
+		{input: "PT0,5S", want: 500 * time.Millisecond},
+		{input: " p1d ", want: 24 * time.Hour},
+		{input: "", wantErr: true},
+		{input: "P", wantErr: true},
+		{input: "PT", wantErr: true},
+		{input: "P1DT", wantErr: true},
+		{input: "P1H", wantErr: true},
+		{input: "1D", wantErr: true},
+		{input: "P292Y", want: 292 * 365 * 24 * time.Hour},
+		{input: "-P292Y", want: -292 * 365 * 24 * time.Hour},
+		{input: "P300Y", wantErr: true},
+		{input: "P292YT10000H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDurationISO(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDurationISO(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDurationISO(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if got, err := ParseDuration("P1DT1H"); err != nil || got != 25*time.Hour {
+		t.Errorf("ParseDuration(%q) = %v, %v, want %v", "P1DT1H", got, err, 25*time.Hour)
+	}
+	for _, input := range []string{"300y", "292y10000h", "9223372036854775808ns"} {
+		if got, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want an out of range error", input, got)
+		}
+	}
+	if got, err := ParseDuration("292y"); err != nil || got != 292*365*24*time.Hour {
+		t.Errorf("ParseDuration(%q) = %v, %v, want %v", "292y", got, err, 292*365*24*time.Hour)
+	}
+}
+
+func TestAddDuration(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "month", start: date(2024, 2, 1, 0), input: "1mo", want: date(2024, 3, 1, 0)},
+		{name: "month overflow", start: date(2024, 1, 31, 0), input: "1month", want: date(2024, 3, 2, 0)},
+		{name: "leap year", start: date(2024, 2, 29, 0), input: "1y", want: date(2025, 3, 1, 0)},
+		{name: "fraction of month", start: date(2024, 1, 1, 0), input: "1.5mo", want: date(2024, 2, 15, 12)},
+		{name: "mixed units", start: date(2023, 1, 1, 0), input: "1y 2d 3h", want: date(2024, 1, 3, 3)},
+		{name: "negative", start: date(2024, 3, 1, 0), input: "-1mo1d", want: date(2024, 1, 31, 0)},
+		{name: "fixed only", start: date(2024, 1, 1, 0), input: "36h", want: date(2024, 1, 2, 12)},
+		{name: "invalid", start: date(2024, 1, 1, 0), input: "abc", wantErr: true},
+		{name: "invalid unit", start: date(2024, 1, 1, 0), input: "1mo2x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddDuration(tt.start, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddDuration(%v, %q) error = %v, wantErr %v", tt.start, tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("AddDuration(%v, %q) = %v, want %v", tt.start, tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDurationFrom(t *testing.T) {
+	tests := []struct {
+		ref   time.Time
+		input string
+		want  time.Duration
+	}{
+		{ref: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), input: "1mo", want: 28 * 24 * time.Hour},
+		{ref: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), input: "1mo", want: 29 * 24 * time.Hour},
+		{ref: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), input: "1y", want: 366 * 24 * time.Hour},
+		{ref: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), input: "90m", want: 90 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDurationFrom(tt.input, tt.ref)
+		if err != nil {
+			t.Fatalf("ParseDurationFrom(%q, %v) error = %v", tt.input, tt.ref, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseDurationFrom(%q, %v) = %v, want %v", tt.input, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDurationHuman(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name string
+		d    time.Duration
+		opts HumanDurationOptions
+		want string
+	}{
+		{name: "zero", d: 0, want: "0 seconds"},
+		{name: "zero compact", d: 0, opts: HumanDurationOptions{Compact: true}, want: "0s"},
+		{name: "singular", d: time.Hour, want: "1 hour"},
+		{name: "plural", d: 2*day + 3*time.Hour, want: "2 days, 3 hours"},
+		{name: "compact", d: 2*day + 3*time.Hour, opts: HumanDurationOptions{Compact: true}, want: "2d 3h"},
+		{name: "years and weeks", d: 400 * day, want: "1 year, 5 weeks"},
+		{name: "milliseconds", d: 1500 * time.Millisecond, want: "1 second, 500 milliseconds"},
+		{name: "below milliseconds", d: 1500 * time.Microsecond, want: "1 millisecond"},
+		{name: "negative", d: -90 * time.Second, opts: HumanDurationOptions{Compact: true}, want: "-1m 30s"},
+		{name: "max units", d: 90*time.Minute + 30*time.Second, opts: HumanDurationOptions{MaxUnits: 1}, want: "1 hour"},
+		{
+			name: "max units rounded",
+			d:    90*time.Minute + 30*time.Second,
+			opts: HumanDurationOptions{MaxUnits: 1, Round: true},
+			want: "2 hours",
+		},
+		{
+			name: "rounding carries over",
+			d:    day - time.Second,
+			opts: HumanDurationOptions{MaxUnits: 2, Round: true},
+			want: "1 day",
+		},
+		{
+			name: "separator",
+			d:    time.Hour + time.Minute,
+			opts: HumanDurationOptions{Separator: " and "},
+			want: "1 hour and 1 minute",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDurationHuman(tt.d, tt.opts); got != tt.want {
+				t.Errorf("FormatDurationHuman(%v, %+v) = %q, want %q", tt.d, tt.opts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelTime(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "same time", t: now, want: "just now"},
+		{name: "recent past", t: now.Add(-5 * time.Second), want: "just now"},
+		{name: "near future", t: now.Add(9 * time.Second), want: "just now"},
+		{name: "past", t: now.Add(-3 * 24 * time.Hour), want: "3 days ago"},
+		{name: "future", t: now.Add(2 * time.Hour), want: "in 2 hours"},
+		{name: "rounded", t: now.Add(-110 * time.Minute), want: "2 hours ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RelTime(tt.t, now); got != tt.want {
+				t.Errorf("RelTime(%v, %v) = %q, want %q", tt.t, now, got, tt.want)
+			}
+		})
+	}
+
+	opts := RelTimeOptions{Duration: HumanDurationOptions{Compact: true}}
+	if got := RelTimeWith(now.Add(-time.Second), now, opts); got != "1s ago" {
+		t.Errorf("RelTimeWith() = %q, want %q", got, "1s ago")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2024-01-02T15:04:05Z", want: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{input: "2024-01-02T15:04:05.123+02:00", want: time.Date(2024, 1, 2, 13, 4, 5, 123000000, time.UTC)},
+		{input: "2024-01-02T15:04:05", want: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{input: "2024-01-02 15:04", want: time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{input: " 2024-01-02 ", want: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{input: "02/Jan/2024:15:04:05 +0100", want: time.Date(2024, 1, 2, 14, 4, 5, 0, time.UTC)},
+		{input: "1700000000", want: time.Unix(1700000000, 0)},
+		{input: "1700000000123", want: time.UnixMilli(1700000000123)},
+		{input: "1700000000.5", want: time.Unix(1700000000, 500000000)},
+		{input: "", wantErr: true},
+		{input: "yesterday", wantErr: true},
+		{input: "2024-13-01", wantErr: true},
+		{input: "20240102", wantErr: true},
+		{input: "12345", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTime(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTime(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		input string
+		loc   *time.Location
+		want  time.Time
+	}{
+		{input: "2024-01-02 15:04", loc: berlin, want: time.Date(2024, 1, 2, 14, 4, 0, 0, time.UTC)},
+		{input: "2024-07-02 15:04", loc: berlin, want: time.Date(2024, 7, 2, 13, 4, 0, 0, time.UTC)},
+		{input: "2024-01-02T15:04:05Z", loc: berlin, want: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{input: "2024-01-02 15:04 Europe/Berlin", loc: time.UTC, want: time.Date(2024, 1, 2, 14, 4, 0, 0, time.UTC)},
+		{input: "2024-01-02 15:04 UTC+2", loc: time.UTC, want: time.Date(2024, 1, 2, 13, 4, 0, 0, time.UTC)},
+		{input: "2024-01-02 15:04 -0530", loc: time.UTC, want: time.Date(2024, 1, 2, 20, 34, 0, 0, time.UTC)},
+		{input: "Tue, 02 Jan 2024 15:04:05 CET", loc: time.UTC, want: time.Date(2024, 1, 2, 14, 4, 5, 0, time.UTC)},
+		{input: "Tue, 02 Jan 2024 15:04:05 EST", loc: time.UTC, want: time.Date(2024, 1, 2, 20, 4, 5, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTimeInLocation(tt.input, tt.loc)
+			if err != nil {
+				t.Fatalf("ParseTimeInLocation(%q, %v) error = %v", tt.input, tt.loc, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimeInLocation(%q, %v) = %v, want %v", tt.input, tt.loc, got, tt.want)
+			}
+		})
+	}
+
+	got, err := ParseTimeInLocation("1700000000", berlin)
+	if err != nil || got.Location() != berlin || got.Unix() != 1700000000 {
+		t.Errorf("ParseTimeInLocation(%q, %v) = %v, %v", "1700000000", berlin, got, err)
+	}
+	if _, err := ParseTimeInLocation("2024-01-02 15:04 Nowhere/City", time.UTC); err == nil {
+		t.Error("ParseTimeInLocation() with unknown zone succeeded")
+	}
+}
+
+func TestFromUnixAny(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "seconds", v: 1700000000, want: time.Unix(1700000000, 0)},
+		{name: "negative", v: int64(-1), want: time.Unix(-1, 0)},
+		{name: "uint8", v: uint8(10), want: time.Unix(10, 0)},
+		{name: "milliseconds", v: int64(1700000000123), want: time.UnixMilli(1700000000123)},
+		{name: "microseconds", v: uint64(1700000000123456), want: time.UnixMicro(1700000000123456)},
+		{name: "nanoseconds", v: int64(1700000000123456789), want: time.Unix(0, 1700000000123456789)},
+		{name: "float", v: 1700000000.25, want: time.Unix(1700000000, 250000000)},
+		{name: "float milliseconds", v: 1700000000500.0, want: time.Unix(1700000000, 500000000)},
+		{name: "json number", v: json.Number("1700000000123"), want: time.UnixMilli(1700000000123)},
+		{name: "string", v: " 1700000000 ", want: time.Unix(1700000000, 0)},
+		{name: "string float", v: "1700000000.5", want: time.Unix(1700000000, 500000000)},
+		{name: "uint overflow", v: uint64(math.MaxUint64), wantErr: true},
+		{name: "nan", v: math.NaN(), wantErr: true},
+		{name: "inf", v: math.Inf(1), wantErr: true},
+		{name: "invalid string", v: "abc", wantErr: true},
+		{name: "unsupported type", v: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromUnixAny(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromUnixAny(%v) error = %v, wantErr %v", tt.v, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("FromUnixAny(%v) = %v, want %v", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartAndEndOf(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*3600)
+	at := func(month time.Month, day, hour, min, sec, nsec int) time.Time {
+		return time.Date(2024, month, day, hour, min, sec, nsec, loc)
+	}
+	wed := at(5, 15, 13, 45, 30, 500)
+	sun := at(5, 19, 23, 0, 0, 0)
+	last := 999999999
+
+	tests := []struct {
+		name string
+		f    func(time.Time) time.Time
+		t    time.Time
+		want time.Time
+	}{
+		{name: "StartOfDay", f: StartOfDay, t: wed, want: at(5, 15, 0, 0, 0, 0)},
+		{name: "StartOfWeek", f: StartOfWeek, t: wed, want: at(5, 13, 0, 0, 0, 0)},
+		{name: "StartOfWeek sunday", f: StartOfWeek, t: sun, want: at(5, 13, 0, 0, 0, 0)},
+		{name: "StartOfMonth", f: StartOfMonth, t: wed, want: at(5, 1, 0, 0, 0, 0)},
+		{name: "StartOfQuarter", f: StartOfQuarter, t: wed, want: at(4, 1, 0, 0, 0, 0)},
+		{name: "StartOfYear", f: StartOfYear, t: wed, want: at(1, 1, 0, 0, 0, 0)},
+		{name: "EndOfDay", f: EndOfDay, t: wed, want: at(5, 15, 23, 59, 59, last)},
+		{name: "EndOfWeek", f: EndOfWeek, t: wed, want: at(5, 19, 23, 59, 59, last)},
+		{name: "EndOfWeek sunday", f: EndOfWeek, t: sun, want: at(5, 19, 23, 59, 59, last)},
+		{name: "EndOfMonth", f: EndOfMonth, t: wed, want: at(5, 31, 23, 59, 59, last)},
+		{name: "EndOfQuarter", f: EndOfQuarter, t: wed, want: at(6, 30, 23, 59, 59, last)},
+		{name: "EndOfYear", f: EndOfYear, t: wed, want: at(12, 31, 23, 59, 59, last)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.f(tt.t)
+			if !got.Equal(tt.want) || got.Location() != loc {
+				t.Errorf("%s(%v) = %v, want %v", tt.name, tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateTo(t *testing.T) {
+	plus2 := time.FixedZone("UTC+2", 2*3600)
+	india := time.FixedZone("IST", 5*3600+1800)
+
+	tests := []struct {
+		t    time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{t: time.Date(2024, 5, 15, 13, 45, 0, 0, plus2), d: 6 * time.Hour, want: time.Date(2024, 5, 15, 12, 0, 0, 0, plus2)},
+		{t: time.Date(2024, 5, 15, 13, 45, 0, 0, india), d: time.Hour, want: time.Date(2024, 5, 15, 13, 0, 0, 0, india)},
+		{t: time.Date(2024, 5, 15, 13, 45, 0, 0, time.UTC), d: 15 * time.Minute, want: time.Date(2024, 5, 15, 13, 45, 0, 0, time.UTC)},
+		{t: time.Date(2024, 5, 15, 13, 45, 0, 0, plus2), d: 0, want: time.Date(2024, 5, 15, 13, 45, 0, 0, plus2)},
+	}
+
+	for _, tt := range tests {
+		got := TruncateTo(tt.t, tt.d)
+		if !got.Equal(tt.want) || got.Location() != tt.t.Location() {
+			t.Errorf("TruncateTo(%v, %v) = %v, want %v", tt.t, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestDurationHelpers(t *testing.T) {
+	if got := ClampDuration(5*time.Second, time.Second, 3*time.Second); got != 3*time.Second {
+		t.Errorf("ClampDuration() = %v, want %v", got, 3*time.Second)
+	}
+	if got := ClampDuration(0, time.Second, 3*time.Second); got != time.Second {
+		t.Errorf("ClampDuration() = %v, want %v", got, time.Second)
+	}
+	if got := ClampDuration(2*time.Second, time.Second, 3*time.Second); got != 2*time.Second {
+		t.Errorf("ClampDuration() = %v, want %v", got, 2*time.Second)
+	}
+	if got := MinDuration(3*time.Second, time.Minute, -time.Second, time.Second); got != -time.Second {
+		t.Errorf("MinDuration() = %v, want %v", got, -time.Second)
+	}
+	if got := MaxDuration(3*time.Second, time.Minute, -time.Second); got != time.Minute {
+		t.Errorf("MaxDuration() = %v, want %v", got, time.Minute)
+	}
+	if got := MaxDuration(time.Second); got != time.Second {
+		t.Errorf("MaxDuration() = %v, want %v", got, time.Second)
+	}
+	if got := DurationPercent(15*time.Second, time.Minute); got != 25 {
+		t.Errorf("DurationPercent() = %v, want %v", got, 25.0)
+	}
+	if got := DurationPercent(time.Second, 0); got != 0 {
+		t.Errorf("DurationPercent() = %v, want %v", got, 0.0)
+	}
+}
@@ -0,0 +1,80 @@
+package tools
+
+import "time"
+
+// TimeRange is the half-open time interval [Start, End).
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the range or 0 if End is not after Start.
+func (r TimeRange) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// IsEmpty checks whether the range contains no time at all.
+func (r TimeRange) IsEmpty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Contains checks whether the given time lies within the range.
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// Overlaps checks whether both ranges share some time.
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// Intersect returns the time shared by both ranges. The result is empty if the ranges do not overlap.
+func (r TimeRange) Intersect(other TimeRange) TimeRange {
+	result := r
+	if other.Start.After(result.Start) {
+		result.Start = other.Start
+	}
+	if other.End.Before(result.End) {
+		result.End = other.End
+	}
+	if result.End.Before(result.Start) {
+		result.End = result.Start
+	}
+	return result
+}
+
+// Clamp returns the time within the range closest to t. For an empty range, Start is returned.
+func (r TimeRange) Clamp(t time.Time) time.Time {
+	switch {
+	case r.IsEmpty(), t.Before(r.Start):
+		return r.Start
+	case !t.Before(r.End):
+		// As the range is half-open, End itself is excluded
+		return r.End.Add(-1)
+	}
+	return t
+}
+
+// Split divides the range into consecutive ranges of the given length. The last range is shorter if the range's
+// duration is not a multiple of the interval. A non-positive interval returns the range itself.
+func (r TimeRange) Split(interval time.Duration) []TimeRange {
+	if r.IsEmpty() {
+		return []TimeRange{}
+	}
+	if interval <= 0 {
+		return []TimeRange{r}
+	}
+
+	ranges := []TimeRange{}
+	for start := r.Start; start.Before(r.End); start = start.Add(interval) {
+		end := start.Add(interval)
+		if end.After(r.End) {
+			end = r.End
+		}
+		ranges = append(ranges, TimeRange{Start: start, End: end})
+	}
+	return ranges
+}
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeRange(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+	r := TimeRange{Start: at(2), End: at(6)}
+	empty := TimeRange{Start: at(6), End: at(2)}
+
+	if got := r.Duration(); got != 4*time.Hour {
+		t.Errorf("Duration() = %v, want %v", got, 4*time.Hour)
+	}
+	if got := empty.Duration(); got != 0 {
+		t.Errorf("Duration() of empty range = %v, want 0", got)
+	}
+	if r.IsEmpty() || !empty.IsEmpty() || !(TimeRange{Start: at(1), End: at(1)}).IsEmpty() {
+		t.Error("IsEmpty() returned wrong result")
+	}
+
+	contains := map[int]bool{1: false, 2: true, 5: true, 6: false}
+	for hour, want := range contains {
+		if got := r.Contains(at(hour)); got != want {
+			t.Errorf("Contains(%v) = %v, want %v", at(hour), got, want)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		other    TimeRange
+		want     TimeRange
+		overlaps bool
+	}{
+		{name: "inside", other: TimeRange{Start: at(3), End: at(4)}, want: TimeRange{Start: at(3), End: at(4)}, overlaps: true},
+		{name: "partial", other: TimeRange{Start: at(5), End: at(8)}, want: TimeRange{Start: at(5), End: at(6)}, overlaps: true},
+		{name: "adjacent", other: TimeRange{Start: at(6), End: at(8)}, want: TimeRange{Start: at(6), End: at(6)}},
+		{name: "disjoint", other: TimeRange{Start: at(8), End: at(9)}, want: TimeRange{Start: at(8), End: at(8)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Intersect(tt.other); !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Errorf("Intersect(%v) = %v, want %v", tt.other, got, tt.want)
+			}
+			if got := r.Overlaps(tt.other); got != tt.overlaps {
+				t.Errorf("Overlaps(%v) = %v, want %v", tt.other, got, tt.overlaps)
+			}
+		})
+	}
+}
+
+func TestTimeRangeClamp(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := TimeRange{Start: base, End: base.Add(time.Hour)}
+
+	tests := []struct {
+		t    time.Time
+		want time.Time
+	}{
+		{t: base.Add(-time.Minute), want: base},
+		{t: base.Add(time.Minute), want: base.Add(time.Minute)},
+		{t: base.Add(time.Hour), want: base.Add(time.Hour - 1)},
+		{t: base.Add(2 * time.Hour), want: base.Add(time.Hour - 1)},
+	}
+	for _, tt := range tests {
+		if got := r.Clamp(tt.t); !got.Equal(tt.want) {
+			t.Errorf("Clamp(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+
+	empty := TimeRange{Start: base, End: base}
+	if got := empty.Clamp(base.Add(time.Hour)); !got.Equal(base) {
+		t.Errorf("Clamp() on empty range = %v, want %v", got, base)
+	}
+}
+
+func TestTimeRangeSplit(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+	r := TimeRange{Start: at(0), End: at(50)}
+
+	tests := []struct {
+		name     string
+		r        TimeRange
+		interval time.Duration
+		want     []TimeRange
+	}{
+		{
+			name:     "remainder",
+			r:        r,
+			interval: 20 * time.Minute,
+			want:     []TimeRange{{at(0), at(20)}, {at(20), at(40)}, {at(40), at(50)}},
+		},
+		{name: "exact", r: r, interval: 25 * time.Minute, want: []TimeRange{{at(0), at(25)}, {at(25), at(50)}}},
+		{name: "larger interval", r: r, interval: time.Hour, want: []TimeRange{r}},
+		{name: "zero interval", r: r, interval: 0, want: []TimeRange{r}},
+		{name: "empty", r: TimeRange{Start: at(5), End: at(5)}, interval: time.Minute, want: []TimeRange{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Split(tt.interval); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split(%v) = %v, want %v", tt.interval, got, tt.want)
+			}
+		})
+	}
+}
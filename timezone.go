@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeZoneAbbreviations maps common upper-case time zone abbreviations to their offset from UTC in seconds. Many
+// abbreviations are ambiguous; the most common meaning is used.
+var TimeZoneAbbreviations = map[string]int{
+	"UTC":  0,
+	"GMT":  0,
+	"Z":    0,
+	"WET":  0,
+	"WEST": 1 * 3600,
+	"BST":  1 * 3600,
+	"CET":  1 * 3600,
+	"CEST": 2 * 3600,
+	"MEZ":  1 * 3600,
+	"MESZ": 2 * 3600,
+	"EET":  2 * 3600,
+	"EEST": 3 * 3600,
+	"MSK":  3 * 3600,
+	"IST":  5*3600 + 1800,
+	"SGT":  8 * 3600,
+	"HKT":  8 * 3600,
+	"CST":  -6 * 3600,
+	"JST":  9 * 3600,
+	"KST":  9 * 3600,
+	"AEST": 10 * 3600,
+	"AEDT": 11 * 3600,
+	"NZST": 12 * 3600,
+	"NZDT": 13 * 3600,
+	"HST":  -10 * 3600,
+	"AKST": -9 * 3600,
+	"AKDT": -8 * 3600,
+	"PST":  -8 * 3600,
+	"PDT":  -7 * 3600,
+	"MST":  -7 * 3600,
+	"MDT":  -6 * 3600,
+	"CDT":  -5 * 3600,
+	"EST":  -5 * 3600,
+	"EDT":  -4 * 3600,
+}
+
+var (
+	reUTCOffset   = regexp.MustCompile(`^(?:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+	locationCache sync.Map
+)
+
+// ResolveLocation returns the location for the given name, which may be an IANA time zone name like
+// "Europe/Berlin", "Local", an abbreviation listed in TimeZoneAbbreviations like "CET" or an offset from UTC like
+// "+02:00", "-0530" or "UTC+2". Abbreviations and offsets result in fixed zones. Results are cached.
+func ResolveLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := resolveLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
+
+func resolveLocation(name string) (*time.Location, error) {
+	upper := strings.ToUpper(name)
+	switch upper {
+	case "UTC", "Z":
+		return time.UTC, nil
+	case "LOCAL":
+		return time.Local, nil
+	}
+
+	if offset, ok := TimeZoneAbbreviations[upper]; ok {
+		return time.FixedZone(upper, offset), nil
+	}
+
+	if m := reUTCOffset.FindStringSubmatch(upper); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi("0" + m[3])
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid UTC offset: %q", name)
+		}
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(name, offset), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone: %q", name)
+	}
+	return loc, nil
+}
+
+// fixZoneAbbreviation corrects times parsed with an abbreviation unknown to the time package, which assumes a UTC
+// offset of zero in that case, using TimeZoneAbbreviations.
+func fixZoneAbbreviation(t time.Time) time.Time {
+	name, offset := t.Zone()
+	if known, ok := TimeZoneAbbreviations[name]; ok && offset == 0 && known != 0 {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+			time.FixedZone(name, known))
+	}
+	return t
+}
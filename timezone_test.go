@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantName   string
+		wantOffset int
+		wantErr    bool
+	}{
+		{name: "UTC", wantName: "UTC", wantOffset: 0},
+		{name: " z ", wantName: "UTC", wantOffset: 0},
+		{name: "CET", wantName: "CET", wantOffset: 3600},
+		{name: "pdt", wantName: "PDT", wantOffset: -7 * 3600},
+		{name: "+02:00", wantName: "+02:00", wantOffset: 2 * 3600},
+		{name: "-0530", wantName: "-0530", wantOffset: -(5*3600 + 1800)},
+		{name: "UTC+2", wantName: "UTC+2", wantOffset: 2 * 3600},
+		{name: "gmt-3", wantName: "gmt-3", wantOffset: -3 * 3600},
+		{name: "+15", wantErr: true},
+		{name: "+02:60", wantErr: true},
+		{name: "Nowhere/City", wantErr: true},
+	}
+
+	ref := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := ResolveLocation(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveLocation(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if name, offset := ref.In(loc).Zone(); name != tt.wantName || offset != tt.wantOffset {
+				t.Errorf("ResolveLocation(%q) = %s %d, want %s %d", tt.name, name, offset, tt.wantName, tt.wantOffset)
+			}
+		})
+	}
+
+	if loc, err := ResolveLocation("Local"); err != nil || loc != time.Local {
+		t.Errorf("ResolveLocation(%q) = %v, %v, want %v", "Local", loc, err, time.Local)
+	}
+}
+
+func TestResolveLocationIANA(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skip(err)
+	}
+
+	first, err := ResolveLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("ResolveLocation() error = %v", err)
+	}
+	if first.String() != "Europe/Berlin" {
+		t.Errorf("ResolveLocation() = %v, want %v", first, "Europe/Berlin")
+	}
+	if second, _ := ResolveLocation("Europe/Berlin"); second != first {
+		t.Error("ResolveLocation() did not cache the location")
+	}
+}
@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Operations reported by WatchDir.
+const (
+	WatchCreate = "create"
+	WatchModify = "modify"
+	WatchRemove = "remove"
+)
+
+// watchPollInterval is the interval used to scan directories if file system notifications are not available.
+var watchPollInterval = time.Second
+
+// WatchDir watches the given directory for changes and calls onEvent with the path of the affected file and the
+// operation, which is one of WatchCreate, WatchModify or WatchRemove. A renamed file is reported as removed under its
+// old name and created under its new one. If patterns are given, only files whose base name matches any of them are
+// reported. Subdirectories are not watched. If file system notifications are unavailable, the directory is polled.
+// The returned function stops watching.
+func WatchDir(dir string, onEvent func(path string, op string), patterns ...string) (stop func(), err error) {
+	if _, err := os.ReadDir(dir); err != nil {
+		return nil, err
+	}
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	matches := func(path string) bool {
+		if len(patterns) == 0 {
+			return true
+		}
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+				return true
+			}
+		}
+		return false
+	}
+	report := func(path, op string) {
+		if matches(path) {
+			onEvent(path, op)
+		}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+
+	wg.Add(1)
+	if watcher, err := newDirWatcher(dir); err == nil {
+		go func() {
+			defer wg.Done()
+			defer watcher.Close()
+			notifyDir(watcher, done, report)
+		}()
+	} else {
+		go func() {
+			defer wg.Done()
+			pollDir(dir, done, report)
+		}()
+	}
+	return stop, nil
+}
+
+func newDirWatcher(dir string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err = watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// notifyDir translates file system notifications into events until done is closed.
+func notifyDir(watcher *fsnotify.Watcher, done <-chan struct{}, report func(path, op string)) {
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			switch {
+			case event.Has(fsnotify.Create):
+				report(event.Name, WatchCreate)
+			case event.Has(fsnotify.Write):
+				report(event.Name, WatchModify)
+			case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+				report(event.Name, WatchRemove)
+			}
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+type dirEntryState struct {
+	size    int64
+	modTime time.Time
+}
+
+// pollDir scans the directory periodically and reports differences until done is closed.
+func pollDir(dir string, done <-chan struct{}, report func(path, op string)) {
+	scan := func() map[string]dirEntryState {
+		state := map[string]dirEntryState{}
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if info, err := e.Info(); err == nil {
+				state[filepath.Join(dir, e.Name())] = dirEntryState{size: info.Size(), modTime: info.ModTime()}
+			}
+		}
+		return state
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	prev := scan()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		cur := scan()
+		for _, path := range SortNatural(Keys(cur), false) {
+			if old, ok := prev[path]; !ok {
+				report(path, WatchCreate)
+			} else if old != cur[path] {
+				report(path, WatchModify)
+			}
+		}
+		for _, path := range SortNatural(Keys(prev), false) {
+			if _, ok := cur[path]; !ok {
+				report(path, WatchRemove)
+			}
+		}
+		prev = cur
+	}
+}
+
+// Event describes a change reported by WatchPath.
+type Event struct {
+	// Path is the path of the changed file.
+	Path string
+
+	// Op is the operation, one of WatchCreate, WatchModify or WatchRemove.
+	Op string
+}
+
+// WatchPath watches the given file or directory and calls fn for each change once no further changes happened for
+// the debounce duration, so that bursts of events, e.g. while a file is written, are reported once per path. A file
+// is watched through its directory, so it may be replaced or removed and recreated. Directories are watched like
+// WatchDir. Watching stops when the context is canceled, in which case the context's error is returned.
+func WatchPath(ctx context.Context, path string, debounce time.Duration, fn func(Event)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	dir, name := path, ""
+	if !info.IsDir() {
+		dir, name = filepath.Dir(path), filepath.Base(path)
+	}
+
+	events := make(chan Event)
+	stop, err := WatchDir(dir, func(p, op string) {
+		if name != "" && filepath.Base(p) != name {
+			return
+		}
+		select {
+		case events <- Event{Path: p, Op: op}:
+		case <-ctx.Done():
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	pending := map[string]string{}
+	var timer *time.Timer
+	var fire <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return ctx.Err()
+		case e := <-events:
+			if debounce <= 0 {
+				fn(e)
+				continue
+			}
+			// A file created within the burst is reported as created, unless it was removed again.
+			if pending[e.Path] != WatchCreate || e.Op == WatchRemove {
+				pending[e.Path] = e.Op
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(debounce)
+			fire = timer.C
+		case <-fire:
+			for _, p := range SortNatural(Keys(pending), false) {
+				fn(Event{Path: p, Op: pending[p]})
+			}
+			pending = map[string]string{}
+			timer, fire = nil, nil
+		}
+	}
+}
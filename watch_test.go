@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// dirEvent is an event reported by WatchDir.
+type dirEvent struct {
+	path, op string
+}
+
+// waitEvent waits for an event matching path and op, ignoring others.
+func waitEvent(t *testing.T, events <-chan dirEvent, path, op string) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if e.path == path && e.op == op {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no %s event for %s", op, path)
+		}
+	}
+}
+
+func TestWatchDir(t *testing.T) {
+	dir := t.TempDir()
+	events := make(chan dirEvent, 100)
+	stop, err := WatchDir(dir, func(path, op string) {
+		events <- dirEvent{path, op}
+	}, "*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	ignored := filepath.Join(dir, "ignored.log")
+	file := filepath.Join(dir, "new.txt")
+	if err := os.WriteFile(ignored, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, events, file, WatchCreate)
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, events, file, WatchRemove)
+
+	stop()
+	close(events)
+	for e := range events {
+		if e.path == ignored {
+			t.Errorf("WatchDir reported %s for %s, which does not match the pattern", e.op, e.path)
+		}
+	}
+}
+
+func TestWatchDirErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		dir      string
+		patterns []string
+	}{
+		{name: "missing directory", dir: filepath.Join(t.TempDir(), "missing")},
+		{name: "invalid pattern", dir: t.TempDir(), patterns: []string{"["}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := WatchDir(tt.dir, func(string, string) {}, tt.patterns...); err == nil {
+				t.Errorf("WatchDir(%q, %q) returned no error", tt.dir, tt.patterns)
+			}
+		})
+	}
+}
+
+func TestPollDir(t *testing.T) {
+	interval := watchPollInterval
+	watchPollInterval = 10 * time.Millisecond
+	defer func() { watchPollInterval = interval }()
+
+	dir := t.TempDir()
+	events := make(chan dirEvent, 100)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pollDir(dir, done, func(path, op string) {
+			events <- dirEvent{path, op}
+		})
+	}()
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	// Files created before the initial scan are not reported, so create files until one is.
+	var file string
+	for i := 0; file == ""; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("new%d.txt", i))
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case e := <-events:
+			if e.op == WatchCreate {
+				file = e.path
+			}
+		case <-time.After(5 * watchPollInterval):
+		}
+	}
+
+	if err := os.WriteFile(file, []byte("longer"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, events, file, WatchModify)
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, events, file, WatchRemove)
+}
+
+// startWatchPath runs WatchPath in the background and waits until it reports changes of the given file, which it
+// modifies repeatedly until then. Events for that file are not forwarded afterwards.
+func startWatchPath(t *testing.T, path string, debounce time.Duration, probe string) <-chan Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	all := make(chan Event, 100)
+	go WatchPath(ctx, path, debounce, func(e Event) { all <- e })
+
+	deadline := time.After(5 * time.Second)
+	for ready := false; !ready; {
+		if err := os.WriteFile(probe, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case e := <-all:
+			ready = e.Path == probe
+		case <-time.After(2*debounce + 50*time.Millisecond):
+		case <-deadline:
+			t.Fatal("WatchPath() did not report any changes")
+		}
+	}
+
+	events := make(chan Event, 100)
+	go func() {
+		for e := range all {
+			if e.Path != probe || probe == path {
+				events <- e
+			}
+		}
+	}()
+	return events
+}
+
+// collectEvents returns the events reported until no event arrived for the given duration.
+func collectEvents(events <-chan Event, quiet time.Duration) []Event {
+	var result []Event
+	for {
+		select {
+		case e := <-events:
+			result = append(result, e)
+		case <-time.After(quiet):
+			return result
+		}
+	}
+}
+
+func TestWatchPathDebounce(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	if err := os.WriteFile(existing, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	debounce := 200 * time.Millisecond
+	events := startWatchPath(t, dir, debounce, filepath.Join(dir, "probe"))
+
+	// A burst of writes to a new file is reported once as created
+	created := filepath.Join(dir, "created.txt")
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(created, []byte(strings.Repeat("x", i+1)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A file created and removed again within the burst is reported as removed
+	temp := filepath.Join(dir, "temp.txt")
+	if err := os.WriteFile(temp, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(temp); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := collectEvents(events, 2*debounce)
+	want := []Event{{Path: created, Op: WatchCreate}, {Path: existing, Op: WatchModify}, {Path: temp, Op: WatchRemove}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WatchPath() reported %v, want %v", got, want)
+	}
+}
+
+func TestWatchPathFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	other := filepath.Join(dir, "other.yaml")
+	if err := os.WriteFile(file, []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	debounce := 100 * time.Millisecond
+	events := startWatchPath(t, file, debounce, file)
+	collectEvents(events, 2*debounce)
+
+	if err := os.WriteFile(other, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := collectEvents(events, 2*debounce), []Event{{Path: file, Op: WatchModify}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WatchPath() reported %v, want %v", got, want)
+	}
+
+	// The file may be replaced
+	if err := SaveFile(file, []byte("c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := collectEvents(events, 2*debounce)
+	if len(got) != 1 || got[0].Path != file {
+		t.Errorf("WatchPath() reported %v for a replaced file, want a single event for %s", got, file)
+	}
+}
+
+func TestWatchPathStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- WatchPath(ctx, t.TempDir(), 0, func(Event) {}) }()
+	cancel()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("WatchPath() returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchPath() did not return after cancellation")
+	}
+
+	if err := WatchPath(context.Background(), filepath.Join(t.TempDir(), "missing"), 0, nil); !os.IsNotExist(err) {
+		t.Errorf("WatchPath() of a missing path returned %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurringWindow is a set of weekly recurring time windows such as "mon-fri 09:00-17:00, sat 10:00-14:00". Times
+// are wall clock times in the location of the time being checked.
+type RecurringWindow struct {
+	windows []dayWindow
+}
+
+// dayWindow is a window on the given days of the week, with start and end given in minutes since midnight. If end
+// is not after start, the window extends into the following day.
+type dayWindow struct {
+	days       uint8
+	start, end int
+}
+
+// ParseRecurringWindow parses a comma separated list of weekly windows. Each window consists of optional days of
+// the week followed by one or more time ranges, e.g. "mon-fri 09:00-12:00 13:00-17:00". Days are given as names
+// (three-letter or full), ranges ("mon-fri") or lists ("mon,wed"); without days, a window applies to every day.
+// Times use the 24-hour format; 24:00 denotes the end of the day and a range whose end is not after its start, such
+// as "22:00-06:00", extends into the next day.
+func ParseRecurringWindow(spec string) (*RecurringWindow, error) {
+	w := &RecurringWindow{}
+
+	var days uint8
+	for _, part := range strings.Split(strings.ToLower(spec), ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("invalid window specification: %q", spec)
+		}
+
+		var ranges []string
+		for i, f := range fields {
+			if strings.Contains(f, ":") {
+				ranges = fields[i:]
+				break
+			}
+			if i > 0 {
+				return nil, fmt.Errorf("invalid window specification: %q", spec)
+			}
+			d, err := parseWindowDays(f)
+			if err != nil {
+				return nil, err
+			}
+			days |= d
+		}
+
+		// Days without a time range are combined with the next part, as in "mon,wed 09:00-10:00".
+		if len(ranges) == 0 {
+			continue
+		}
+		if days == 0 {
+			days = 1<<7 - 1
+		}
+
+		for _, r := range ranges {
+			start, end, err := parseWindowRange(r)
+			if err != nil {
+				return nil, err
+			}
+			w.windows = append(w.windows, dayWindow{days: days, start: start, end: end})
+		}
+		days = 0
+	}
+	if days != 0 || len(w.windows) == 0 {
+		return nil, fmt.Errorf("invalid window specification: %q", spec)
+	}
+
+	return w, nil
+}
+
+// parseWindowDays parses a day name or a range of days and returns them as a bit set indexed by time.Weekday.
+func parseWindowDays(s string) (uint8, error) {
+	from, to, isRange := strings.Cut(s, "-")
+	first, ok := parseWeekday(from)
+	if !ok {
+		return 0, fmt.Errorf("invalid day of week: %q", s)
+	}
+	last := first
+	if isRange {
+		if last, ok = parseWeekday(to); !ok {
+			return 0, fmt.Errorf("invalid day of week: %q", s)
+		}
+	}
+
+	var days uint8
+	for d := first; ; d = (d + 1) % 7 {
+		days |= 1 << d
+		if d == last {
+			break
+		}
+	}
+	return days, nil
+}
+
+// parseWeekday parses a three-letter or full day name.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseWindowRange parses a time range like "09:00-17:00" and returns start and end in minutes since midnight.
+func parseWindowRange(s string) (start, end int, err error) {
+	from, to, ok := strings.Cut(s, "-")
+	if ok {
+		start, ok = parseClock(from)
+	}
+	if ok {
+		end, ok = parseClock(to)
+	}
+	if !ok || start == 24*60 || start == end {
+		return 0, 0, fmt.Errorf("invalid time range: %q", s)
+	}
+	return start, end, nil
+}
+
+// parseClock parses a time of day in the format HH:MM and returns it in minutes since midnight.
+func parseClock(s string) (int, bool) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok || len(mm) != 2 {
+		return 0, false
+	}
+	h, err1 := strconv.Atoi(hh)
+	m, err2 := strconv.Atoi(mm)
+	if err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+// Contains reports whether t falls within one of the windows.
+func (w *RecurringWindow) Contains(t time.Time) bool {
+	day := t.Weekday()
+	prev := (day + 6) % 7
+	minute := t.Hour()*60 + t.Minute()
+
+	for _, win := range w.windows {
+		if win.start < win.end {
+			if win.days&(1<<day) != 0 && minute >= win.start && minute < win.end {
+				return true
+			}
+			continue
+		}
+		if win.days&(1<<day) != 0 && minute >= win.start {
+			return true
+		}
+		if win.days&(1<<prev) != 0 && minute < win.end {
+			return true
+		}
+	}
+	return false
+}
+
+// NextTransition returns the first time after t at which Contains changes its result, i.e. when the current window
+// closes or the next one opens. It returns the zero time if there is no such time.
+func (w *RecurringWindow) NextTransition(t time.Time) time.Time {
+	var candidates []time.Time
+	year, month, day := t.Date()
+	for i := -1; i <= 8; i++ {
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, t.Location())
+		for _, win := range w.windows {
+			if win.days&(1<<date.Weekday()) == 0 {
+				continue
+			}
+			end := win.end
+			if win.end <= win.start {
+				end += 24 * 60
+			}
+			candidates = append(candidates,
+				time.Date(year, month, day+i, 0, win.start, 0, 0, t.Location()),
+				time.Date(year, month, day+i, 0, end, 0, 0, t.Location()))
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	current := w.Contains(t)
+	for _, c := range candidates {
+		if c.After(t) && w.Contains(c) != current {
+			return c
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurringWindowContains(t *testing.T) {
+	// January 15th, 2024 is a Monday
+	at := func(day, hour, min int) time.Time { return time.Date(2024, 1, day, hour, min, 0, 0, time.UTC) }
+
+	tests := []struct {
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{spec: "mon-fri 09:00-17:00, sat 10:00-14:00", t: at(15, 9, 0), want: true},
+		{spec: "mon-fri 09:00-17:00, sat 10:00-14:00", t: at(15, 8, 59), want: false},
+		{spec: "mon-fri 09:00-17:00, sat 10:00-14:00", t: at(19, 16, 59), want: true},
+		{spec: "mon-fri 09:00-17:00, sat 10:00-14:00", t: at(19, 17, 0), want: false},
+		{spec: "mon-fri 09:00-17:00, sat 10:00-14:00", t: at(20, 11, 0), want: true},
+		{spec: "mon-fri 09:00-17:00, sat 10:00-14:00", t: at(21, 11, 0), want: false},
+		{spec: "Monday 09:00-12:00 13:00-17:00", t: at(15, 12, 30), want: false},
+		{spec: "Monday 09:00-12:00 13:00-17:00", t: at(15, 13, 30), want: true},
+		{spec: "mon,wed 09:00-10:00", t: at(15, 9, 30), want: true},
+		{spec: "mon,wed 09:00-10:00", t: at(16, 9, 30), want: false},
+		{spec: "mon,wed 09:00-10:00", t: at(17, 9, 30), want: true},
+		{spec: "sat-mon 08:00-09:00", t: at(21, 8, 30), want: true},
+		{spec: "sat-mon 08:00-09:00", t: at(16, 8, 30), want: false},
+		// Overnight windows extend into the following day
+		{spec: "22:00-06:00", t: at(15, 23, 0), want: true},
+		{spec: "22:00-06:00", t: at(15, 5, 59), want: true},
+		{spec: "22:00-06:00", t: at(15, 6, 0), want: false},
+		{spec: "22:00-06:00", t: at(15, 12, 0), want: false},
+		{spec: "fri 22:00-06:00", t: at(20, 3, 0), want: true},
+		{spec: "fri 22:00-06:00", t: at(19, 3, 0), want: false},
+		{spec: "fri 22:00-06:00", t: at(20, 23, 0), want: false},
+		{spec: "sat 20:00-24:00", t: at(20, 23, 59), want: true},
+		{spec: "sat 20:00-24:00", t: at(21, 0, 0), want: false},
+	}
+
+	for _, tt := range tests {
+		w, err := ParseRecurringWindow(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseRecurringWindow(%q) error = %v", tt.spec, err)
+		}
+		if got := w.Contains(tt.t); got != tt.want {
+			t.Errorf("ParseRecurringWindow(%q).Contains(%v) = %v, want %v", tt.spec, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestRecurringWindowNextTransition(t *testing.T) {
+	at := func(day, hour, min int) time.Time { return time.Date(2024, 1, day, hour, min, 0, 0, time.UTC) }
+
+	tests := []struct {
+		spec string
+		t    time.Time
+		want time.Time
+	}{
+		{spec: "mon-fri 09:00-17:00", t: at(15, 12, 0), want: at(15, 17, 0)},
+		{spec: "mon-fri 09:00-17:00", t: at(15, 17, 0), want: at(16, 9, 0)},
+		{spec: "mon-fri 09:00-17:00", t: at(19, 18, 0), want: at(22, 9, 0)},
+		{spec: "22:00-06:00", t: at(15, 12, 0), want: at(15, 22, 0)},
+		{spec: "22:00-06:00", t: at(15, 23, 0), want: at(16, 6, 0)},
+		{spec: "mon 00:00-24:00, tue 00:00-24:00", t: at(15, 12, 0), want: at(17, 0, 0)},
+		{spec: "00:00-24:00", t: at(15, 12, 0), want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		w, err := ParseRecurringWindow(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseRecurringWindow(%q) error = %v", tt.spec, err)
+		}
+		if got := w.NextTransition(tt.t); !got.Equal(tt.want) {
+			t.Errorf("ParseRecurringWindow(%q).NextTransition(%v) = %v, want %v", tt.spec, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestParseRecurringWindowErrors(t *testing.T) {
+	specs := []string{
+		"",
+		"mon",
+		"mon,",
+		"09:00",
+		"9-10",
+		"foo 09:00-10:00",
+		"mon tue 09:00-10:00",
+		"09:00-09:00",
+		"24:00-01:00",
+		"09:60-10:00",
+		"25:00-26:00",
+		"09:00-24:01",
+		"09:0-10:00",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseRecurringWindow(spec); err == nil {
+			t.Errorf("ParseRecurringWindow(%q) succeeded, want error", spec)
+		}
+	}
+}